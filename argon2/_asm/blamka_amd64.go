@@ -18,6 +18,8 @@ func main() {
 	ConstraintExpr("amd64,gc,!purego")
 
 	blamkaSSE4()
+	blamkaAVX2()
+	blamkaAVX512()
 	mixBlocksSSE2()
 	xorBlocksSSE2()
 	Generate()
@@ -56,6 +58,69 @@ func blamkaSSE4() {
 	RET()
 }
 
+func blamkaAVX2() {
+	Implement("blamkaAVX2")
+	Attributes(NOSPLIT)
+	AllocLocal(0)
+
+	Load(Param("b"), RAX)
+
+	VBROADCASTI128(c40_DATA(), Y14)
+	VBROADCASTI128(c48_DATA(), Y15)
+
+	rotr := func(n int, v VecPhysical) {
+		switch n {
+		case 32:
+			VPSHUFD(Imm(0xB1), v, v)
+		case 24:
+			VPSHUFB(Y14, v, v)
+		case 16:
+			VPSHUFB(Y15, v, v)
+		case 63:
+			VPADDQ(v, v, Y9)
+			VPSRLQ(Imm(63), v, v)
+			VPXOR(Y9, v, v)
+		}
+	}
+
+	BLAMKA_ROUND_AVX2_0(AX, 0, Y8, rotr)
+	BLAMKA_ROUND_AVX2_0(AX, 32, Y8, rotr)
+	BLAMKA_ROUND_AVX2_0(AX, 64, Y8, rotr)
+	BLAMKA_ROUND_AVX2_0(AX, 96, Y8, rotr)
+
+	BLAMKA_ROUND_AVX2_1(AX, 0, Y8, rotr)
+	BLAMKA_ROUND_AVX2_1(AX, 4, Y8, rotr)
+	BLAMKA_ROUND_AVX2_1(AX, 8, Y8, rotr)
+	BLAMKA_ROUND_AVX2_1(AX, 12, Y8, rotr)
+	VZEROUPPER()
+	RET()
+}
+
+// blamkaAVX512 is blamkaAVX2 with the rotations done by VPRORQ, which requires AVX-512F and AVX-512VL.
+func blamkaAVX512() {
+	Implement("blamkaAVX512")
+	Attributes(NOSPLIT)
+	AllocLocal(0)
+
+	Load(Param("b"), RAX)
+
+	rotr := func(n int, v VecPhysical) {
+		VPRORQ(Imm(uint64(n)), v, v)
+	}
+
+	BLAMKA_ROUND_AVX2_0(AX, 0, Y8, rotr)
+	BLAMKA_ROUND_AVX2_0(AX, 32, Y8, rotr)
+	BLAMKA_ROUND_AVX2_0(AX, 64, Y8, rotr)
+	BLAMKA_ROUND_AVX2_0(AX, 96, Y8, rotr)
+
+	BLAMKA_ROUND_AVX2_1(AX, 0, Y8, rotr)
+	BLAMKA_ROUND_AVX2_1(AX, 4, Y8, rotr)
+	BLAMKA_ROUND_AVX2_1(AX, 8, Y8, rotr)
+	BLAMKA_ROUND_AVX2_1(AX, 12, Y8, rotr)
+	VZEROUPPER()
+	RET()
+}
+
 func mixBlocksSSE2() {
 	Implement("mixBlocksSSE2")
 	Attributes(NOSPLIT)
@@ -259,6 +324,113 @@ func BLAMKA_ROUND_1(block GPPhysical, off int, t0, t1, c40, c48 VecPhysical) {
 	STORE_MSG_1(block, off)
 }
 
+// The AVX2 rounds hold two groups of 16 words in Y0-Y3 and Y4-Y7, each group as the four rows of a 4x4 matrix of
+// words, so that each G function is applied to the four columns of the matrix at once.
+
+// BLAMKA_ADD_AVX2 computes a = a + b + 2*lo(a)*lo(b) for each word.
+func BLAMKA_ADD_AVX2(a, b, t0 VecPhysical) {
+	VPMULUDQ(b, a, t0)
+	VPADDQ(b, a, a)
+	VPADDQ(t0, a, a)
+	VPADDQ(t0, a, a)
+}
+
+func G_AVX2(t0 VecPhysical, rotr func(n int, v VecPhysical)) {
+	var groups = [][4]VecPhysical{{Y0, Y1, Y2, Y3}, {Y4, Y5, Y6, Y7}}
+	for _, g := range groups {
+		BLAMKA_ADD_AVX2(g[0], g[1], t0)
+		VPXOR(g[0], g[3], g[3])
+		rotr(32, g[3])
+	}
+	for _, g := range groups {
+		BLAMKA_ADD_AVX2(g[2], g[3], t0)
+		VPXOR(g[2], g[1], g[1])
+		rotr(24, g[1])
+	}
+	for _, g := range groups {
+		BLAMKA_ADD_AVX2(g[0], g[1], t0)
+		VPXOR(g[0], g[3], g[3])
+		rotr(16, g[3])
+	}
+	for _, g := range groups {
+		BLAMKA_ADD_AVX2(g[2], g[3], t0)
+		VPXOR(g[2], g[1], g[1])
+		rotr(63, g[1])
+	}
+}
+
+func DIAGONALIZE_AVX2() {
+	for _, g := range [][3]VecPhysical{{Y1, Y2, Y3}, {Y5, Y6, Y7}} {
+		VPERMQ(Imm(0x39), g[0], g[0])
+		VPERMQ(Imm(0x4E), g[1], g[1])
+		VPERMQ(Imm(0x93), g[2], g[2])
+	}
+}
+
+func UNDIAGONALIZE_AVX2() {
+	for _, g := range [][3]VecPhysical{{Y1, Y2, Y3}, {Y5, Y6, Y7}} {
+		VPERMQ(Imm(0x93), g[0], g[0])
+		VPERMQ(Imm(0x4E), g[1], g[1])
+		VPERMQ(Imm(0x39), g[2], g[2])
+	}
+}
+
+func ROUND_AVX2(t0 VecPhysical, rotr func(n int, v VecPhysical)) {
+	G_AVX2(t0, rotr)
+	DIAGONALIZE_AVX2()
+	G_AVX2(t0, rotr)
+	UNDIAGONALIZE_AVX2()
+}
+
+// LOAD_MSG_AVX2_0 loads the two groups of 16 consecutive words starting at word off.
+func LOAD_MSG_AVX2_0(block GPPhysical, off int) {
+	var registers = []VecPhysical{Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7}
+	for i, r := range registers {
+		VMOVDQU(Mem{Base: block}.Offset(8*(off+(i*4))), r)
+	}
+}
+
+func STORE_MSG_AVX2_0(block GPPhysical, off int) {
+	var registers = []VecPhysical{Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7}
+	for i, r := range registers {
+		VMOVDQU(r, Mem{Base: block}.Offset(8*(off+(i*4))))
+	}
+}
+
+// LOAD_MSG_AVX2_1 loads the two groups of words in columns off, off+1 and off+2, off+3 of the 8 rows of 16 words.
+// Each register holds two words from each of two consecutive rows.
+func LOAD_MSG_AVX2_1(block GPPhysical, off int) {
+	var registers = [][]VecPhysical{{Y0, Y1, Y2, Y3}, {Y4, Y5, Y6, Y7}}
+	for g, group := range registers {
+		for i, r := range group {
+			VMOVDQU(Mem{Base: block}.Offset(8*(off+2*g+i*32)), r.AsX())
+			VINSERTI128(Imm(1), Mem{Base: block}.Offset(8*(off+2*g+i*32+16)), r, r)
+		}
+	}
+}
+
+func STORE_MSG_AVX2_1(block GPPhysical, off int) {
+	var registers = [][]VecPhysical{{Y0, Y1, Y2, Y3}, {Y4, Y5, Y6, Y7}}
+	for g, group := range registers {
+		for i, r := range group {
+			VMOVDQU(r.AsX(), Mem{Base: block}.Offset(8*(off+2*g+i*32)))
+			VEXTRACTI128(Imm(1), r, Mem{Base: block}.Offset(8*(off+2*g+i*32+16)))
+		}
+	}
+}
+
+func BLAMKA_ROUND_AVX2_0(block GPPhysical, off int, t0 VecPhysical, rotr func(n int, v VecPhysical)) {
+	LOAD_MSG_AVX2_0(block, off)
+	ROUND_AVX2(t0, rotr)
+	STORE_MSG_AVX2_0(block, off)
+}
+
+func BLAMKA_ROUND_AVX2_1(block GPPhysical, off int, t0 VecPhysical, rotr func(n int, v VecPhysical)) {
+	LOAD_MSG_AVX2_1(block, off)
+	ROUND_AVX2(t0, rotr)
+	STORE_MSG_AVX2_1(block, off)
+}
+
 // ##------------------DATA SECTION-------------------##
 
 var c40_DATA_ptr, c48_DATA_ptr *Mem
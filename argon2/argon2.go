@@ -11,6 +11,16 @@
 // If you aren't sure which function you need, use Argon2id (IDKey) and
 // the parameter recommendations for your scenario.
 //
+// The IDKey, IKey, and DKey functions panic if the parameters are invalid.
+// Where the parameters come from configuration use the DeriveIDKey, DeriveIKey,
+// and DeriveDKey functions instead, which return an error. The variant can be
+// selected by name using ParseMode and Mode.KeyFunc.
+//
+// The memory in use by concurrent derivations can be limited process-wide
+// using the memlimit package. The limit applies to the DeriveIDKey, DeriveIKey,
+// and DeriveDKey functions, their Context variants, and Hasher, but not to the
+// IDKey, IKey, and DKey functions or their WithSecret variants.
+//
 // # Argon2id
 //
 // Argon2id (implemented by IDKey) is a hybrid version of Argon2 combining
@@ -39,15 +49,21 @@
 package argon2
 
 import (
+	"context"
 	"encoding/binary"
 	"sync"
 
 	"github.com/go-crypt/x/blake2b"
+	"github.com/go-crypt/x/memlimit"
 )
 
 // KeyFunc represents the functions which can derive keys.
 type KeyFunc func(password, salt []byte, time, memory, threads, keyLen uint32) []byte
 
+// KeyWithSecretFunc represents the functions which can derive keys using a
+// secret and associated data.
+type KeyWithSecretFunc func(password, salt, secret, data []byte, time, memory, threads, keyLen uint32) []byte
+
 // IDKey derives a key from the password, salt, and cost parameters using
 // Argon2id returning a byte slice of length keyLen that can be used as
 // cryptographic key. The CPU cost and parallelism degree must be greater than
@@ -69,25 +85,117 @@ type KeyFunc func(password, salt []byte, time, memory, threads, keyLen uint32) [
 // increased as memory latency and CPU parallelism increases. Remember to get a
 // good random salt.
 func IDKey(password, salt []byte, time, memory uint32, threads, keyLen uint32) []byte {
-	return deriveKey(argon2id, password, salt, nil, nil, time, memory, threads, keyLen)
+	return deriveKey(Argon2id, password, salt, nil, nil, time, memory, threads, keyLen)
+}
+
+// IDKeyWithSecret is like IDKey but additionally accepts the optional secret
+// value K and associated data X defined in RFC 9106 Section 3.1. Either may be
+// nil.
+//
+// The secret can be used as a pepper which is kept separate from the stored
+// hashes, and the associated data can be used to bind the derived key to a
+// context such as a tenant or user identifier.
+func IDKeyWithSecret(password, salt, secret, data []byte, time, memory, threads, keyLen uint32) []byte {
+	return deriveKey(Argon2id, password, salt, secret, data, time, memory, threads, keyLen)
+}
+
+// DeriveIDKey is like IDKeyWithSecret but takes the cost parameters and
+// optional inputs from params. Instead of panicking it returns an error if
+// params or the salt are outside the bounds of RFC 9106 Section 3.1, see
+// Params.Validate for the error types. A salt shorter than MinSaltLength
+// returns an InvalidSaltLengthError.
+func DeriveIDKey(password, salt []byte, params Params) ([]byte, error) {
+	return deriveKeyChecked(context.Background(), Argon2id, password, salt, params)
+}
+
+// DeriveIDKeyContext is like DeriveIDKey but stops the derivation between each
+// slice of the memory once the context is done, returning the context's error.
+func DeriveIDKeyContext(ctx context.Context, password, salt []byte, params Params) ([]byte, error) {
+	return deriveKeyChecked(ctx, Argon2id, password, salt, params)
 }
 
-func deriveKey(mode int, password, salt, secret, data []byte, time, memory uint32, threads, keyLen uint32) []byte {
+func deriveKey(mode Mode, password, salt, secret, data []byte, time, memory uint32, threads, keyLen uint32) []byte {
 	if time < 1 {
 		panic("argon2: number of rounds too small")
 	}
 	if threads < 1 {
 		panic("argon2: parallelism degree too low")
 	}
-	h0 := initHash(password, salt, secret, data, time, memory, threads, keyLen, mode)
+	// The default memlimit.Limiter is not consulted as these functions can
+	// neither return an error nor be cancelled while waiting for memory.
+	params := Params{
+		Time:    time,
+		Memory:  memory,
+		Threads: threads,
+		KeyLen:  keyLen,
+		Secret:  secret,
+		Data:    data,
+	}
+	B := make([]block, blocks(memory, threads))
+	key, _ := deriveBlocks(context.Background(), B, mode, password, salt, params)
+	return key
+}
 
-	memory = memory / (syncPoints * uint32(threads)) * (syncPoints * uint32(threads))
-	if memory < 2*syncPoints*uint32(threads) {
-		memory = 2 * syncPoints * uint32(threads)
+func deriveKeyChecked(ctx context.Context, mode Mode, password, salt []byte, params Params) ([]byte, error) {
+	if !mode.valid() {
+		return nil, InvalidModeError(mode.String())
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkSalt(salt); err != nil {
+		return nil, err
+	}
+	return derive(ctx, mode, password, salt, params)
+}
+
+func derive(ctx context.Context, mode Mode, password, salt []byte, params Params) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	n := blocks(params.Memory, params.Threads)
+	release, err := memlimit.Acquire(ctx, uint64(n)*blockSize)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return deriveBlocks(ctx, make([]block, n), mode, password, salt, params)
+}
+
+// deriveBlocks derives the key using B as the memory, which must have the
+// length returned by blocks and must not be used by another derivation
+// concurrently.
+func deriveBlocks(ctx context.Context, B []block, mode Mode, password, salt []byte, params Params) ([]byte, error) {
+	time, memory, threads, keyLen := params.Time, params.Memory, params.Threads, params.KeyLen
+	version := params.version()
+	h0 := initHash(password, salt, params.Secret, params.Data,
+		time, memory, threads, keyLen, version, mode)
+
+	var (
+		trace  *tracer
+		onPass func(n uint32)
+	)
+	if params.Trace != nil {
+		trace = newTracer(params.Trace)
+		trace.init(mode, password, salt, params, version, h0[:blake2b.Size])
+		onPass = func(n uint32) { trace.pass(n, B) }
 	}
-	B := initBlocks(&h0, memory, threads)
-	processBlocks(B, time, memory, threads, mode)
-	return extractKey(B, memory, threads, keyLen)
+
+	memory = uint32(len(B))
+	initBlocks(&h0, B, threads)
+	err := processBlocks(ctx, params.executor(), B, time, memory, threads,
+		version, mode, onPass)
+	if err != nil {
+		return nil, err
+	}
+	key := extractKey(B, memory, threads, keyLen)
+
+	if trace != nil {
+		if err := trace.tag(key); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
 }
 
 // IKey derives a key from the password, salt, and cost parameters using Argon2i
@@ -110,19 +218,59 @@ func deriveKey(mode int, password, salt, secret, data []byte, time, memory uint3
 // increased as memory latency and CPU parallelism increases. Remember to get a
 // good random salt.
 func IKey(password, salt []byte, time, memory uint32, threads, keyLen uint32) []byte {
-	return deriveKey(argon2i, password, salt, nil, nil, time, memory, threads, keyLen)
+	return deriveKey(Argon2i, password, salt, nil, nil, time, memory, threads, keyLen)
+}
+
+// IKeyWithSecret is like IKey but additionally accepts the optional secret
+// value K and associated data X defined in RFC 9106 Section 3.1. Either may be
+// nil.
+func IKeyWithSecret(password, salt, secret, data []byte, time, memory, threads, keyLen uint32) []byte {
+	return deriveKey(Argon2i, password, salt, secret, data, time, memory, threads, keyLen)
+}
+
+// DeriveIKey is like IKeyWithSecret but takes the cost parameters and optional
+// inputs from params. It returns an error instead of panicking in the same
+// manner as DeriveIDKey.
+func DeriveIKey(password, salt []byte, params Params) ([]byte, error) {
+	return deriveKeyChecked(context.Background(), Argon2i, password, salt, params)
+}
+
+// DeriveIKeyContext is like DeriveIKey but stops the derivation in the same
+// manner as DeriveIDKeyContext.
+func DeriveIKeyContext(ctx context.Context, password, salt []byte, params Params) ([]byte, error) {
+	return deriveKeyChecked(ctx, Argon2i, password, salt, params)
 }
 
 // DKey derives a key from the password, salt, and cost parameters using Argon2d
 // returning a byte slice of length keyLen that can be used as cryptographic
 // key. The CPU cost and parallelism degree must be greater than zero.
 func DKey(password, salt []byte, time, memory, threads, keyLen uint32) []byte {
-	return deriveKey(argon2d, password, salt, nil, nil, time, memory, threads, keyLen)
+	return deriveKey(Argon2d, password, salt, nil, nil, time, memory, threads, keyLen)
+}
+
+// DKeyWithSecret is like DKey but additionally accepts the optional secret
+// value K and associated data X defined in RFC 9106 Section 3.1. Either may be
+// nil.
+func DKeyWithSecret(password, salt, secret, data []byte, time, memory, threads, keyLen uint32) []byte {
+	return deriveKey(Argon2d, password, salt, secret, data, time, memory, threads, keyLen)
+}
+
+// DeriveDKey is like DKeyWithSecret but takes the cost parameters and optional
+// inputs from params. It returns an error instead of panicking in the same
+// manner as DeriveIDKey.
+func DeriveDKey(password, salt []byte, params Params) ([]byte, error) {
+	return deriveKeyChecked(context.Background(), Argon2d, password, salt, params)
+}
+
+// DeriveDKeyContext is like DeriveDKey but stops the derivation in the same
+// manner as DeriveIDKeyContext.
+func DeriveDKeyContext(ctx context.Context, password, salt []byte, params Params) ([]byte, error) {
+	return deriveKeyChecked(ctx, Argon2d, password, salt, params)
 }
 
 type block [blockLength]uint64
 
-func initHash(password, salt, key, data []byte, time, memory, threads, keyLen uint32, mode int) [blake2b.Size + 8]byte {
+func initHash(password, salt, key, data []byte, time, memory, threads, keyLen, version uint32, mode Mode) [blake2b.Size + 8]byte {
 	var (
 		h0     [blake2b.Size + 8]byte
 		params [24]byte
@@ -134,7 +282,7 @@ func initHash(password, salt, key, data []byte, time, memory, threads, keyLen ui
 	binary.LittleEndian.PutUint32(params[4:8], keyLen)
 	binary.LittleEndian.PutUint32(params[8:12], memory)
 	binary.LittleEndian.PutUint32(params[12:16], time)
-	binary.LittleEndian.PutUint32(params[16:20], uint32(Version))
+	binary.LittleEndian.PutUint32(params[16:20], version)
 	binary.LittleEndian.PutUint32(params[20:24], uint32(mode))
 	b2.Write(params[:])
 	binary.LittleEndian.PutUint32(tmp[:], uint32(len(password)))
@@ -153,9 +301,19 @@ func initHash(password, salt, key, data []byte, time, memory, threads, keyLen ui
 	return h0
 }
 
-func initBlocks(h0 *[blake2b.Size + 8]byte, memory, threads uint32) []block {
+// blocks returns the number of blocks used for the memory size in KiB, which is
+// rounded down to a multiple of 4*threads with a minimum of 8*threads.
+func blocks(memory, threads uint32) uint32 {
+	memory = memory / (syncPoints * uint32(threads)) * (syncPoints * uint32(threads))
+	if memory < 2*syncPoints*uint32(threads) {
+		memory = 2 * syncPoints * uint32(threads)
+	}
+	return memory
+}
+
+func initBlocks(h0 *[blake2b.Size + 8]byte, B []block, threads uint32) {
 	var block0 [1024]byte
-	B := make([]block, memory)
+	memory := uint32(len(B))
 	for lane := uint32(0); lane < threads; lane++ {
 		j := lane * (memory / threads)
 		binary.LittleEndian.PutUint32(h0[blake2b.Size+4:], lane)
@@ -172,16 +330,15 @@ func initBlocks(h0 *[blake2b.Size + 8]byte, memory, threads uint32) []block {
 			B[j+1][i] = binary.LittleEndian.Uint64(block0[i*8:])
 		}
 	}
-	return B
 }
 
-func processBlocks(B []block, time, memory, threads uint32, mode int) {
+func processBlocks(ctx context.Context, executor Executor, B []block, time, memory, threads, version uint32, mode Mode, onPass func(n uint32)) error {
 	lanes := memory / threads
 	segments := lanes / syncPoints
 
 	processSegment := func(n, slice, lane uint32, wg *sync.WaitGroup) {
 		var addresses, in, zero block
-		if mode == argon2i || (mode == argon2id && n == 0 && slice < syncPoints/2) {
+		if mode == Argon2i || (mode == Argon2id && n == 0 && slice < syncPoints/2) {
 			in[0] = uint64(n)
 			in[1] = uint64(lane)
 			in[2] = uint64(slice)
@@ -193,7 +350,7 @@ func processBlocks(B []block, time, memory, threads uint32, mode int) {
 		index := uint32(0)
 		if n == 0 && slice == 0 {
 			index = 2 // we have already generated the first two blocks
-			if mode == argon2i || mode == argon2id {
+			if mode == Argon2i || mode == Argon2id {
 				in[6]++
 				processBlock(&addresses, &in, &zero)
 				processBlock(&addresses, &addresses, &zero)
@@ -207,7 +364,7 @@ func processBlocks(B []block, time, memory, threads uint32, mode int) {
 			if index == 0 && slice == 0 {
 				prev += lanes // last block in lane
 			}
-			if mode == argon2i || (mode == argon2id && n == 0 && slice < syncPoints/2) {
+			if mode == Argon2i || (mode == Argon2id && n == 0 && slice < syncPoints/2) {
 				if index%blockLength == 0 {
 					in[6]++
 					processBlock(&addresses, &in, &zero)
@@ -218,7 +375,13 @@ func processBlocks(B []block, time, memory, threads uint32, mode int) {
 				random = B[prev][0]
 			}
 			newOffset := indexAlpha(random, lanes, segments, threads, n, slice, lane, index)
-			processBlockXOR(&B[offset], &B[prev], &B[newOffset])
+			if n == 0 || version == Version10 {
+				// The first pass, and every pass of version 1.0, overwrites the block
+				// instead of XOR'ing it.
+				processBlock(&B[offset], &B[prev], &B[newOffset])
+			} else {
+				processBlockXOR(&B[offset], &B[prev], &B[newOffset])
+			}
 			index, offset = index+1, offset+1
 		}
 		wg.Done()
@@ -226,15 +389,21 @@ func processBlocks(B []block, time, memory, threads uint32, mode int) {
 
 	for n := uint32(0); n < time; n++ {
 		for slice := uint32(0); slice < syncPoints; slice++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 			var wg sync.WaitGroup
 			for lane := uint32(0); lane < threads; lane++ {
 				wg.Add(1)
-				go processSegment(n, slice, lane, &wg)
+				executor.Go(func() { processSegment(n, slice, lane, &wg) })
 			}
 			wg.Wait()
 		}
+		if onPass != nil {
+			onPass(n)
+		}
 	}
-
+	return nil
 }
 
 func extractKey(B []block, memory, threads, keyLen uint32) []byte {
@@ -6,8 +6,14 @@ package argon2
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
+	"errors"
+	"math/rand/v2"
 	"testing"
+	"time"
+
+	"github.com/go-crypt/x/memlimit"
 )
 
 var (
@@ -23,8 +29,24 @@ var (
 )
 
 func TestArgon2(t *testing.T) {
-	defer func(sse4 bool) { useSSE4 = sse4 }(useSSE4)
+	defer func(sse4, avx2, avx512 bool) {
+		useSSE4, useAVX2, useAVX512 = sse4, avx2, avx512
+	}(useSSE4, useAVX2, useAVX512)
 
+	if useAVX512 {
+		t.Log("AVX-512 version")
+		testArgon2i(t)
+		testArgon2d(t)
+		testArgon2id(t)
+		useAVX512 = false
+	}
+	if useAVX2 {
+		t.Log("AVX2 version")
+		testArgon2i(t)
+		testArgon2d(t)
+		testArgon2id(t)
+		useAVX2 = false
+	}
 	if useSSE4 {
 		t.Log("SSE4.1 version")
 		testArgon2i(t)
@@ -45,7 +67,7 @@ func testArgon2d(t *testing.T) {
 		0xf8, 0x68, 0xe3, 0xbe, 0x39, 0x84, 0xf3, 0xc1,
 		0xa1, 0x3a, 0x4d, 0xb9, 0xfa, 0xbe, 0x4a, 0xcb,
 	}
-	hash := deriveKey(argon2d, genKatPassword, genKatSalt, genKatSecret, genKatAAD, 3, 32, 4, 32)
+	hash := deriveKey(Argon2d, genKatPassword, genKatSalt, genKatSecret, genKatAAD, 3, 32, 4, 32)
 	if !bytes.Equal(hash, want) {
 		t.Errorf("derived key does not match - got: %s , want: %s", hex.EncodeToString(hash), hex.EncodeToString(want))
 	}
@@ -58,7 +80,7 @@ func testArgon2i(t *testing.T) {
 		0xc8, 0xde, 0x6b, 0x01, 0x6d, 0xd3, 0x88, 0xd2,
 		0x99, 0x52, 0xa4, 0xc4, 0x67, 0x2b, 0x6c, 0xe8,
 	}
-	hash := deriveKey(argon2i, genKatPassword, genKatSalt, genKatSecret, genKatAAD, 3, 32, 4, 32)
+	hash := deriveKey(Argon2i, genKatPassword, genKatSalt, genKatSecret, genKatAAD, 3, 32, 4, 32)
 	if !bytes.Equal(hash, want) {
 		t.Errorf("derived key does not match - got: %s , want: %s", hex.EncodeToString(hash), hex.EncodeToString(want))
 	}
@@ -71,12 +93,218 @@ func testArgon2id(t *testing.T) {
 		0xd0, 0x1e, 0xf0, 0x45, 0x2d, 0x75, 0xb6, 0x5e,
 		0xb5, 0x25, 0x20, 0xe9, 0x6b, 0x01, 0xe6, 0x59,
 	}
-	hash := deriveKey(argon2id, genKatPassword, genKatSalt, genKatSecret, genKatAAD, 3, 32, 4, 32)
+	hash := deriveKey(Argon2id, genKatPassword, genKatSalt, genKatSecret, genKatAAD, 3, 32, 4, 32)
 	if !bytes.Equal(hash, want) {
 		t.Errorf("derived key does not match - got: %s , want: %s", hex.EncodeToString(hash), hex.EncodeToString(want))
 	}
 }
 
+func TestKeyWithSecret(t *testing.T) {
+	testCases := []struct {
+		name string
+		key  KeyWithSecretFunc
+		want string
+	}{
+		{"Argon2d", DKeyWithSecret, "512b391b6f1162975371d30919734294f868e3be3984f3c1a13a4db9fabe4acb"},
+		{"Argon2i", IKeyWithSecret, "c814d9d1dc7f37aa13f0d77f2494bda1c8de6b016dd388d29952a4c4672b6ce8"},
+		{"Argon2id", IDKeyWithSecret, "0d640df58d78766c08c037a34a8b53c9d01ef0452d75b65eb52520e96b01e659"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hash := tc.key(genKatPassword, genKatSalt, genKatSecret, genKatAAD, 3, 32, 4, 32)
+			if got := hex.EncodeToString(hash); got != tc.want {
+				t.Errorf("derived key does not match - got: %s , want: %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	params := Params{Time: 3, Memory: 32, Threads: 4, KeyLen: 32, Secret: genKatSecret, Data: genKatAAD}
+
+	testCases := []struct {
+		name   string
+		derive func(password, salt []byte, params Params) ([]byte, error)
+		want   string
+	}{
+		{"Argon2d", DeriveDKey, "512b391b6f1162975371d30919734294f868e3be3984f3c1a13a4db9fabe4acb"},
+		{"Argon2i", DeriveIKey, "c814d9d1dc7f37aa13f0d77f2494bda1c8de6b016dd388d29952a4c4672b6ce8"},
+		{"Argon2id", DeriveIDKey, "0d640df58d78766c08c037a34a8b53c9d01ef0452d75b65eb52520e96b01e659"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hash, err := tc.derive(genKatPassword, genKatSalt, params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := hex.EncodeToString(hash); got != tc.want {
+				t.Errorf("derived key does not match - got: %s , want: %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDeriveKeyErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		salt   []byte
+		params Params
+		err    error
+	}{
+		{"ShouldErrTimeZero", genKatSalt, Params{Time: 0, Memory: 32, Threads: 4, KeyLen: 32}, InvalidTimeError(0)},
+		{"ShouldErrThreadsZero", genKatSalt, Params{Time: 1, Memory: 32, Threads: 0, KeyLen: 32}, InvalidThreadsError(0)},
+		{"ShouldErrThreadsTooHigh", genKatSalt, Params{Time: 1, Memory: 32, Threads: MaxThreads + 1, KeyLen: 32}, InvalidThreadsError(MaxThreads + 1)},
+		{"ShouldErrMemoryTooLow", genKatSalt, Params{Time: 1, Memory: 31, Threads: 4, KeyLen: 32}, InvalidMemoryError{Memory: 31, Threads: 4}},
+		{"ShouldErrKeyLenTooShort", genKatSalt, Params{Time: 1, Memory: 32, Threads: 4, KeyLen: 3}, InvalidKeyLengthError(3)},
+		{"ShouldErrSaltTooShort", genKatSalt[:7], Params{Time: 1, Memory: 32, Threads: 4, KeyLen: 32}, InvalidSaltLengthError(7)},
+		{"ShouldErrSaltNil", nil, Params{Time: 1, Memory: 32, Threads: 4, KeyLen: 32}, InvalidSaltLengthError(0)},
+		{"ShouldErrVersionUnknown", genKatSalt, Params{Time: 1, Memory: 32, Threads: 4, KeyLen: 32, Version: 0x12}, InvalidVersionError(0x12)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, derive := range []func(password, salt []byte, params Params) ([]byte, error){DeriveIDKey, DeriveIKey, DeriveDKey} {
+				hash, err := derive(genKatPassword, tc.salt, tc.params)
+				if err != tc.err {
+					t.Errorf("got err %v but should have given %v", err, tc.err)
+				}
+				if hash != nil {
+					t.Errorf("expected nil key but got %x", hash)
+				}
+			}
+		})
+	}
+}
+
+func TestDeriveKeyContext(t *testing.T) {
+	params := Params{Time: 3, Memory: 32, Threads: 4, KeyLen: 32, Secret: genKatSecret, Data: genKatAAD}
+
+	hash, err := DeriveIDKeyContext(context.Background(), genKatPassword, genKatSalt, params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := hex.EncodeToString(hash), "0d640df58d78766c08c037a34a8b53c9d01ef0452d75b65eb52520e96b01e659"; got != want {
+		t.Errorf("derived key does not match - got: %s , want: %s", got, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, derive := range []func(ctx context.Context, password, salt []byte, params Params) ([]byte, error){DeriveIDKeyContext, DeriveIKeyContext, DeriveDKeyContext} {
+		if hash, err = derive(ctx, genKatPassword, genKatSalt, params); !errors.Is(err, context.Canceled) {
+			t.Errorf("got err %v but should have given %v", err, context.Canceled)
+		}
+		if hash != nil {
+			t.Errorf("expected nil key but got %x", hash)
+		}
+	}
+}
+
+func TestDeriveKeyContextDeadline(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long running derivation in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	hash, err := DeriveIDKeyContext(ctx, []byte("password"), []byte("somesalt"), Params{Time: 100, Memory: 64 * 1024, Threads: 1, KeyLen: 32})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got err %v but should have given %v", err, context.DeadlineExceeded)
+	}
+	if hash != nil {
+		t.Errorf("expected nil key but got %x", hash)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("derivation should have stopped shortly after the deadline but took %s", elapsed)
+	}
+}
+
+func TestDeriveKeyMemoryLimit(t *testing.T) {
+	defer memlimit.SetDefault(nil)
+
+	l := memlimit.NewLimiter(64*1024, false)
+	memlimit.SetDefault(l)
+
+	if _, err := DeriveIDKey(genKatPassword, genKatSalt, Params{Time: 1, Memory: 64, Threads: 1, KeyLen: 32}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := DeriveIDKey(genKatPassword, genKatSalt, Params{Time: 1, Memory: 128, Threads: 1, KeyLen: 32}); err != memlimit.ErrExceedsLimit {
+		t.Errorf("got err %v but should have given %v", err, memlimit.ErrExceedsLimit)
+	}
+
+	// The legacy functions do not consult the limiter, so they must neither panic nor block.
+	if IDKey(genKatPassword, genKatSalt, 1, 128, 1, 32) == nil {
+		t.Errorf("expected a key regardless of the memory limit")
+	}
+
+	release, err := memlimit.Acquire(t.Context(), 64*1024)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if IDKey(genKatPassword, genKatSalt, 1, 64, 1, 32) == nil {
+		t.Errorf("expected a key while the memory limit is reached")
+	}
+
+	release()
+
+	h, err := NewHasher(Params{Time: 1, Memory: 128, Threads: 1, KeyLen: 32})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err = h.IDKey(t.Context(), genKatPassword, genKatSalt); err != memlimit.ErrExceedsLimit {
+		t.Errorf("got err %v but should have given %v", err, memlimit.ErrExceedsLimit)
+	}
+
+	if l.InUse() != 0 {
+		t.Errorf("expected the memory to be released but %d bytes are in use", l.InUse())
+	}
+}
+
+func TestDeriveKeyVersion10(t *testing.T) {
+	params := Params{Time: 3, Memory: 32, Threads: 4, KeyLen: 32, Secret: genKatSecret, Data: genKatAAD, Version: Version10}
+
+	testCases := []struct {
+		name   string
+		derive func(password, salt []byte, params Params) ([]byte, error)
+		want   string
+	}{
+		{"Argon2d", DeriveDKey, "96a9d4e5a1734092c85e29f410a45914a5dd1f5cbf08b2670da68a0285abf32b"},
+		{"Argon2i", DeriveIKey, "87aeedd6517ab830cd9765cd8231abb2e647a5dee08f7c05e02fcb763335d0fd"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hash, err := tc.derive(genKatPassword, genKatSalt, params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := hex.EncodeToString(hash); got != tc.want {
+				t.Errorf("derived key does not match - got: %s , want: %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestVersion10Vectors(t *testing.T) {
+	for i, v := range testVectorsVersion10 {
+		hash, err := DeriveIKey([]byte(v.password), []byte(v.salt), Params{Time: v.time, Memory: v.memory, Threads: v.threads, KeyLen: 32, Version: Version10})
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i, err)
+		}
+		if got := hex.EncodeToString(hash); got != v.hash {
+			t.Errorf("Test %d - got: %s want: %s", i, got, v.hash)
+		}
+	}
+}
+
 func TestVectors(t *testing.T) {
 	password, salt := []byte("password"), []byte("somesalt")
 	for i, v := range testVectors {
@@ -91,7 +319,69 @@ func TestVectors(t *testing.T) {
 	}
 }
 
-func benchmarkArgon2(mode int, time, memory uint32, threads, keyLen uint32, b *testing.B) {
+// blamkaImplementations returns the block compression implementations supported by the CPU, and a function which
+// restores the implementation in use before.
+func blamkaImplementations() (impls []blamkaImplementation, restore func()) {
+	sse4, avx2, avx512 := useSSE4, useAVX2, useAVX512
+
+	for _, impl := range []blamkaImplementation{
+		{"AVX-512", avx512, false, false, true},
+		{"AVX2", avx2, false, true, false},
+		{"SSE4.1", sse4, true, false, false},
+		{"generic", true, false, false, false},
+	} {
+		if impl.supported {
+			impls = append(impls, impl)
+		}
+	}
+
+	return impls, func() { useSSE4, useAVX2, useAVX512 = sse4, avx2, avx512 }
+}
+
+type blamkaImplementation struct {
+	name               string
+	supported          bool
+	sse4, avx2, avx512 bool
+}
+
+func (impl blamkaImplementation) use() {
+	useSSE4, useAVX2, useAVX512 = impl.sse4, impl.avx2, impl.avx512
+}
+
+func TestProcessBlock(t *testing.T) {
+	impls, restore := blamkaImplementations()
+	defer restore()
+
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	var in1, in2, out, want, got block
+
+	for i := 0; i < 64; i++ {
+		for j := range out {
+			in1[j], in2[j], out[j] = rng.Uint64(), rng.Uint64(), rng.Uint64()
+		}
+
+		for _, impl := range impls {
+			impl.use()
+
+			want, got = out, out
+			processBlockGeneric(&want, &in1, &in2, false)
+			processBlock(&got, &in1, &in2)
+			if got != want {
+				t.Fatalf("%s: processBlock does not match the generic implementation for block %d", impl.name, i)
+			}
+
+			want, got = out, out
+			processBlockGeneric(&want, &in1, &in2, true)
+			processBlockXOR(&got, &in1, &in2)
+			if got != want {
+				t.Fatalf("%s: processBlockXOR does not match the generic implementation for block %d", impl.name, i)
+			}
+		}
+	}
+}
+
+func benchmarkArgon2(mode Mode, time, memory uint32, threads, keyLen uint32, b *testing.B) {
 	password := []byte("password")
 	salt := []byte("choosing random salts is hard")
 	b.ReportAllocs()
@@ -101,132 +391,188 @@ func benchmarkArgon2(mode int, time, memory uint32, threads, keyLen uint32, b *t
 }
 
 func BenchmarkArgon2i(b *testing.B) {
-	b.Run(" Time: 3 Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(argon2i, 3, 32*1024, 1, 32, b) })
-	b.Run(" Time: 4 Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(argon2i, 4, 32*1024, 1, 32, b) })
-	b.Run(" Time: 5 Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(argon2i, 5, 32*1024, 1, 32, b) })
-	b.Run(" Time: 3 Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(argon2i, 3, 64*1024, 4, 32, b) })
-	b.Run(" Time: 4 Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(argon2i, 4, 64*1024, 4, 32, b) })
-	b.Run(" Time: 5 Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(argon2i, 5, 64*1024, 4, 32, b) })
+	b.Run(" Time: 3 Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(Argon2i, 3, 32*1024, 1, 32, b) })
+	b.Run(" Time: 4 Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(Argon2i, 4, 32*1024, 1, 32, b) })
+	b.Run(" Time: 5 Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(Argon2i, 5, 32*1024, 1, 32, b) })
+	b.Run(" Time: 3 Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(Argon2i, 3, 64*1024, 4, 32, b) })
+	b.Run(" Time: 4 Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(Argon2i, 4, 64*1024, 4, 32, b) })
+	b.Run(" Time: 5 Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(Argon2i, 5, 64*1024, 4, 32, b) })
 }
 
 func BenchmarkArgon2d(b *testing.B) {
-	b.Run(" Time: 3, Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(argon2d, 3, 32*1024, 1, 32, b) })
-	b.Run(" Time: 4, Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(argon2d, 4, 32*1024, 1, 32, b) })
-	b.Run(" Time: 5, Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(argon2d, 5, 32*1024, 1, 32, b) })
-	b.Run(" Time: 3, Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(argon2d, 3, 64*1024, 4, 32, b) })
-	b.Run(" Time: 4, Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(argon2d, 4, 64*1024, 4, 32, b) })
-	b.Run(" Time: 5, Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(argon2d, 5, 64*1024, 4, 32, b) })
+	b.Run(" Time: 3, Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(Argon2d, 3, 32*1024, 1, 32, b) })
+	b.Run(" Time: 4, Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(Argon2d, 4, 32*1024, 1, 32, b) })
+	b.Run(" Time: 5, Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(Argon2d, 5, 32*1024, 1, 32, b) })
+	b.Run(" Time: 3, Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(Argon2d, 3, 64*1024, 4, 32, b) })
+	b.Run(" Time: 4, Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(Argon2d, 4, 64*1024, 4, 32, b) })
+	b.Run(" Time: 5, Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(Argon2d, 5, 64*1024, 4, 32, b) })
 }
 
 func BenchmarkArgon2id(b *testing.B) {
-	b.Run(" Time: 3, Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(argon2id, 3, 32*1024, 1, 32, b) })
-	b.Run(" Time: 4, Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(argon2id, 4, 32*1024, 1, 32, b) })
-	b.Run(" Time: 5, Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(argon2id, 5, 32*1024, 1, 32, b) })
-	b.Run(" Time: 3, Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(argon2id, 3, 64*1024, 4, 32, b) })
-	b.Run(" Time: 4, Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(argon2id, 4, 64*1024, 4, 32, b) })
-	b.Run(" Time: 5, Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(argon2id, 5, 64*1024, 4, 32, b) })
+	b.Run(" Time: 3, Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(Argon2id, 3, 32*1024, 1, 32, b) })
+	b.Run(" Time: 4, Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(Argon2id, 4, 32*1024, 1, 32, b) })
+	b.Run(" Time: 5, Memory: 32 MB, Threads: 1", func(b *testing.B) { benchmarkArgon2(Argon2id, 5, 32*1024, 1, 32, b) })
+	b.Run(" Time: 3, Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(Argon2id, 3, 64*1024, 4, 32, b) })
+	b.Run(" Time: 4, Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(Argon2id, 4, 64*1024, 4, 32, b) })
+	b.Run(" Time: 5, Memory: 64 MB, Threads: 4", func(b *testing.B) { benchmarkArgon2(Argon2id, 5, 64*1024, 4, 32, b) })
+}
+
+func BenchmarkProcessBlock(b *testing.B) {
+	impls, restore := blamkaImplementations()
+	defer restore()
+
+	var in1, in2, out block
+	for i := range in1 {
+		in1[i], in2[i] = uint64(i), uint64(i)<<32
+	}
+
+	for _, impl := range impls {
+		b.Run(impl.name, func(b *testing.B) {
+			impl.use()
+			b.SetBytes(blockSize)
+			for i := 0; i < b.N; i++ {
+				processBlockXOR(&out, &in1, &in2)
+			}
+		})
+	}
 }
 
 // Generated with the CLI of https://github.com/P-H-C/phc-winner-argon2/blob/master/argon2-specs.pdf
 var testVectors = []struct {
-	mode                  int
+	mode                  Mode
 	time, memory, threads uint32
 	hash                  string
 }{
 	{
-		mode: argon2i, time: 1, memory: 64, threads: 1,
+		mode: Argon2i, time: 1, memory: 64, threads: 1,
 		hash: "b9c401d1844a67d50eae3967dc28870b22e508092e861a37",
 	},
 	{
-		mode: argon2d, time: 1, memory: 64, threads: 1,
+		mode: Argon2d, time: 1, memory: 64, threads: 1,
 		hash: "8727405fd07c32c78d64f547f24150d3f2e703a89f981a19",
 	},
 	{
-		mode: argon2id, time: 1, memory: 64, threads: 1,
+		mode: Argon2id, time: 1, memory: 64, threads: 1,
 		hash: "655ad15eac652dc59f7170a7332bf49b8469be1fdb9c28bb",
 	},
 	{
-		mode: argon2i, time: 2, memory: 64, threads: 1,
+		mode: Argon2i, time: 2, memory: 64, threads: 1,
 		hash: "8cf3d8f76a6617afe35fac48eb0b7433a9a670ca4a07ed64",
 	},
 	{
-		mode: argon2d, time: 2, memory: 64, threads: 1,
+		mode: Argon2d, time: 2, memory: 64, threads: 1,
 		hash: "3be9ec79a69b75d3752acb59a1fbb8b295a46529c48fbb75",
 	},
 	{
-		mode: argon2id, time: 2, memory: 64, threads: 1,
+		mode: Argon2id, time: 2, memory: 64, threads: 1,
 		hash: "068d62b26455936aa6ebe60060b0a65870dbfa3ddf8d41f7",
 	},
 	{
-		mode: argon2i, time: 2, memory: 64, threads: 2,
+		mode: Argon2i, time: 2, memory: 64, threads: 2,
 		hash: "2089f3e78a799720f80af806553128f29b132cafe40d059f",
 	},
 	{
-		mode: argon2d, time: 2, memory: 64, threads: 2,
+		mode: Argon2d, time: 2, memory: 64, threads: 2,
 		hash: "68e2462c98b8bc6bb60ec68db418ae2c9ed24fc6748a40e9",
 	},
 	{
-		mode: argon2id, time: 2, memory: 64, threads: 2,
+		mode: Argon2id, time: 2, memory: 64, threads: 2,
 		hash: "350ac37222f436ccb5c0972f1ebd3bf6b958bf2071841362",
 	},
 	{
-		mode: argon2i, time: 3, memory: 256, threads: 2,
+		mode: Argon2i, time: 3, memory: 256, threads: 2,
 		hash: "f5bbf5d4c3836af13193053155b73ec7476a6a2eb93fd5e6",
 	},
 	{
-		mode: argon2d, time: 3, memory: 256, threads: 2,
+		mode: Argon2d, time: 3, memory: 256, threads: 2,
 		hash: "f4f0669218eaf3641f39cc97efb915721102f4b128211ef2",
 	},
 	{
-		mode: argon2id, time: 3, memory: 256, threads: 2,
+		mode: Argon2id, time: 3, memory: 256, threads: 2,
 		hash: "4668d30ac4187e6878eedeacf0fd83c5a0a30db2cc16ef0b",
 	},
 	{
-		mode: argon2i, time: 4, memory: 4096, threads: 4,
+		mode: Argon2i, time: 4, memory: 4096, threads: 4,
 		hash: "a11f7b7f3f93f02ad4bddb59ab62d121e278369288a0d0e7",
 	},
 	{
-		mode: argon2d, time: 4, memory: 4096, threads: 4,
+		mode: Argon2d, time: 4, memory: 4096, threads: 4,
 		hash: "935598181aa8dc2b720914aa6435ac8d3e3a4210c5b0fb2d",
 	},
 	{
-		mode: argon2id, time: 4, memory: 4096, threads: 4,
+		mode: Argon2id, time: 4, memory: 4096, threads: 4,
 		hash: "145db9733a9f4ee43edf33c509be96b934d505a4efb33c5a",
 	},
 	{
-		mode: argon2i, time: 4, memory: 1024, threads: 8,
+		mode: Argon2i, time: 4, memory: 1024, threads: 8,
 		hash: "0cdd3956aa35e6b475a7b0c63488822f774f15b43f6e6e17",
 	},
 	{
-		mode: argon2d, time: 4, memory: 1024, threads: 8,
+		mode: Argon2d, time: 4, memory: 1024, threads: 8,
 		hash: "83604fc2ad0589b9d055578f4d3cc55bc616df3578a896e9",
 	},
 	{
-		mode: argon2id, time: 4, memory: 1024, threads: 8,
+		mode: Argon2id, time: 4, memory: 1024, threads: 8,
 		hash: "8dafa8e004f8ea96bf7c0f93eecf67a6047476143d15577f",
 	},
 	{
-		mode: argon2i, time: 2, memory: 64, threads: 3,
+		mode: Argon2i, time: 2, memory: 64, threads: 3,
 		hash: "5cab452fe6b8479c8661def8cd703b611a3905a6d5477fe6",
 	},
 	{
-		mode: argon2d, time: 2, memory: 64, threads: 3,
+		mode: Argon2d, time: 2, memory: 64, threads: 3,
 		hash: "22474a423bda2ccd36ec9afd5119e5c8949798cadf659f51",
 	},
 	{
-		mode: argon2id, time: 2, memory: 64, threads: 3,
+		mode: Argon2id, time: 2, memory: 64, threads: 3,
 		hash: "4a15b31aec7c2590b87d1f520be7d96f56658172deaa3079",
 	},
 	{
-		mode: argon2i, time: 3, memory: 1024, threads: 6,
+		mode: Argon2i, time: 3, memory: 1024, threads: 6,
 		hash: "d236b29c2b2a09babee842b0dec6aa1e83ccbdea8023dced",
 	},
 	{
-		mode: argon2d, time: 3, memory: 1024, threads: 6,
+		mode: Argon2d, time: 3, memory: 1024, threads: 6,
 		hash: "a3351b0319a53229152023d9206902f4ef59661cdca89481",
 	},
 	{
-		mode: argon2id, time: 3, memory: 1024, threads: 6,
+		mode: Argon2id, time: 3, memory: 1024, threads: 6,
 		hash: "1640b932f4b60e272f5d2207b9a9c626ffa1bd88d2349016",
 	},
 }
+
+// Taken from the Argon2i version 1.0 tests of https://github.com/P-H-C/phc-winner-argon2/blob/master/src/test.c
+var testVectorsVersion10 = []struct {
+	password, salt        string
+	time, memory, threads uint32
+	hash                  string
+}{
+	{
+		password: "password", salt: "somesalt", time: 2, memory: 1 << 16, threads: 1,
+		hash: "f6c4db4a54e2a370627aff3db6176b94a2a209a62c8e36152711802f7b30c694",
+	},
+	{
+		password: "password", salt: "somesalt", time: 2, memory: 1 << 8, threads: 1,
+		hash: "fd4dd83d762c49bdeaf57c47bdcd0c2f1babf863fdeb490df63ede9975fccf06",
+	},
+	{
+		password: "password", salt: "somesalt", time: 2, memory: 1 << 8, threads: 2,
+		hash: "b6c11560a6a9d61eac706b79a2f97d68b4463aa3ad87e00c07e2b01e90c564fb",
+	},
+	{
+		password: "password", salt: "somesalt", time: 1, memory: 1 << 16, threads: 1,
+		hash: "81630552b8f3b1f48cdb1992c4c678643d490b2b5eb4ff6c4b3438b5621724b2",
+	},
+	{
+		password: "password", salt: "somesalt", time: 4, memory: 1 << 16, threads: 1,
+		hash: "f212f01615e6eb5d74734dc3ef40ade2d51d052468d8c69440a3a1f2c1c2847b",
+	},
+	{
+		password: "differentpassword", salt: "somesalt", time: 2, memory: 1 << 16, threads: 1,
+		hash: "e9c902074b6754531a3a0be519e5baf404b30ce69b3f01ac3bf21229960109a3",
+	},
+	{
+		password: "password", salt: "diffsalt", time: 2, memory: 1 << 16, threads: 1,
+		hash: "79a103b90fe8aef8570cb31fc8b22259778916f8336b7bdac3892569d4f1c497",
+	},
+}
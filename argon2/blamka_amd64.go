@@ -9,6 +9,8 @@ package argon2
 import "golang.org/x/sys/cpu"
 
 func init() {
+	useAVX512 = cpu.X86.HasAVX512F && cpu.X86.HasAVX512VL
+	useAVX2 = cpu.X86.HasAVX2
 	useSSE4 = cpu.X86.HasSSE41
 }
 
@@ -21,12 +23,23 @@ func xorBlocksSSE2(out, a, b, c *block)
 //go:noescape
 func blamkaSSE4(b *block)
 
+//go:noescape
+func blamkaAVX2(b *block)
+
+//go:noescape
+func blamkaAVX512(b *block)
+
 func processBlockSSE(out, in1, in2 *block, xor bool) {
 	var t block
 	mixBlocksSSE2(&t, in1, in2, &t)
-	if useSSE4 {
+	switch {
+	case useAVX512:
+		blamkaAVX512(&t)
+	case useAVX2:
+		blamkaAVX2(&t)
+	case useSSE4:
 		blamkaSSE4(&t)
-	} else {
+	default:
 		for i := 0; i < blockLength; i += 16 {
 			blamkaGeneric(
 				&t[i+0], &t[i+1], &t[i+2], &t[i+3],
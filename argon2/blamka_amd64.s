@@ -2740,6 +2740,2198 @@ DATA ·c48<>+0(SB)/8, $0x0100070605040302
 DATA ·c48<>+8(SB)/8, $0x09080f0e0d0c0b0a
 GLOBL ·c48<>(SB), RODATA|NOPTR, $16
 
+// func blamkaAVX2(b *block)
+// Requires: AVX, AVX2
+TEXT ·blamkaAVX2(SB), NOSPLIT, $0-8
+	MOVQ           b+0(FP), AX
+	VBROADCASTI128 ·c40<>+0(SB), Y14
+	VBROADCASTI128 ·c48<>+0(SB), Y15
+	VMOVDQU        (AX), Y0
+	VMOVDQU        32(AX), Y1
+	VMOVDQU        64(AX), Y2
+	VMOVDQU        96(AX), Y3
+	VMOVDQU        128(AX), Y4
+	VMOVDQU        160(AX), Y5
+	VMOVDQU        192(AX), Y6
+	VMOVDQU        224(AX), Y7
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFD        $0xb1, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFD        $0xb1, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPSHUFB        Y14, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPSHUFB        Y14, Y5, Y5
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFB        Y15, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFB        Y15, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPADDQ         Y1, Y1, Y9
+	VPSRLQ         $0x3f, Y1, Y1
+	VPXOR          Y9, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPADDQ         Y5, Y5, Y9
+	VPSRLQ         $0x3f, Y5, Y5
+	VPXOR          Y9, Y5, Y5
+	VPERMQ         $0x39, Y1, Y1
+	VPERMQ         $0x4e, Y2, Y2
+	VPERMQ         $0x93, Y3, Y3
+	VPERMQ         $0x39, Y5, Y5
+	VPERMQ         $0x4e, Y6, Y6
+	VPERMQ         $0x93, Y7, Y7
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFD        $0xb1, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFD        $0xb1, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPSHUFB        Y14, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPSHUFB        Y14, Y5, Y5
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFB        Y15, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFB        Y15, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPADDQ         Y1, Y1, Y9
+	VPSRLQ         $0x3f, Y1, Y1
+	VPXOR          Y9, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPADDQ         Y5, Y5, Y9
+	VPSRLQ         $0x3f, Y5, Y5
+	VPXOR          Y9, Y5, Y5
+	VPERMQ         $0x93, Y1, Y1
+	VPERMQ         $0x4e, Y2, Y2
+	VPERMQ         $0x39, Y3, Y3
+	VPERMQ         $0x93, Y5, Y5
+	VPERMQ         $0x4e, Y6, Y6
+	VPERMQ         $0x39, Y7, Y7
+	VMOVDQU        Y0, (AX)
+	VMOVDQU        Y1, 32(AX)
+	VMOVDQU        Y2, 64(AX)
+	VMOVDQU        Y3, 96(AX)
+	VMOVDQU        Y4, 128(AX)
+	VMOVDQU        Y5, 160(AX)
+	VMOVDQU        Y6, 192(AX)
+	VMOVDQU        Y7, 224(AX)
+	VMOVDQU        256(AX), Y0
+	VMOVDQU        288(AX), Y1
+	VMOVDQU        320(AX), Y2
+	VMOVDQU        352(AX), Y3
+	VMOVDQU        384(AX), Y4
+	VMOVDQU        416(AX), Y5
+	VMOVDQU        448(AX), Y6
+	VMOVDQU        480(AX), Y7
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFD        $0xb1, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFD        $0xb1, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPSHUFB        Y14, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPSHUFB        Y14, Y5, Y5
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFB        Y15, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFB        Y15, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPADDQ         Y1, Y1, Y9
+	VPSRLQ         $0x3f, Y1, Y1
+	VPXOR          Y9, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPADDQ         Y5, Y5, Y9
+	VPSRLQ         $0x3f, Y5, Y5
+	VPXOR          Y9, Y5, Y5
+	VPERMQ         $0x39, Y1, Y1
+	VPERMQ         $0x4e, Y2, Y2
+	VPERMQ         $0x93, Y3, Y3
+	VPERMQ         $0x39, Y5, Y5
+	VPERMQ         $0x4e, Y6, Y6
+	VPERMQ         $0x93, Y7, Y7
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFD        $0xb1, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFD        $0xb1, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPSHUFB        Y14, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPSHUFB        Y14, Y5, Y5
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFB        Y15, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFB        Y15, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPADDQ         Y1, Y1, Y9
+	VPSRLQ         $0x3f, Y1, Y1
+	VPXOR          Y9, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPADDQ         Y5, Y5, Y9
+	VPSRLQ         $0x3f, Y5, Y5
+	VPXOR          Y9, Y5, Y5
+	VPERMQ         $0x93, Y1, Y1
+	VPERMQ         $0x4e, Y2, Y2
+	VPERMQ         $0x39, Y3, Y3
+	VPERMQ         $0x93, Y5, Y5
+	VPERMQ         $0x4e, Y6, Y6
+	VPERMQ         $0x39, Y7, Y7
+	VMOVDQU        Y0, 256(AX)
+	VMOVDQU        Y1, 288(AX)
+	VMOVDQU        Y2, 320(AX)
+	VMOVDQU        Y3, 352(AX)
+	VMOVDQU        Y4, 384(AX)
+	VMOVDQU        Y5, 416(AX)
+	VMOVDQU        Y6, 448(AX)
+	VMOVDQU        Y7, 480(AX)
+	VMOVDQU        512(AX), Y0
+	VMOVDQU        544(AX), Y1
+	VMOVDQU        576(AX), Y2
+	VMOVDQU        608(AX), Y3
+	VMOVDQU        640(AX), Y4
+	VMOVDQU        672(AX), Y5
+	VMOVDQU        704(AX), Y6
+	VMOVDQU        736(AX), Y7
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFD        $0xb1, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFD        $0xb1, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPSHUFB        Y14, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPSHUFB        Y14, Y5, Y5
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFB        Y15, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFB        Y15, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPADDQ         Y1, Y1, Y9
+	VPSRLQ         $0x3f, Y1, Y1
+	VPXOR          Y9, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPADDQ         Y5, Y5, Y9
+	VPSRLQ         $0x3f, Y5, Y5
+	VPXOR          Y9, Y5, Y5
+	VPERMQ         $0x39, Y1, Y1
+	VPERMQ         $0x4e, Y2, Y2
+	VPERMQ         $0x93, Y3, Y3
+	VPERMQ         $0x39, Y5, Y5
+	VPERMQ         $0x4e, Y6, Y6
+	VPERMQ         $0x93, Y7, Y7
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFD        $0xb1, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFD        $0xb1, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPSHUFB        Y14, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPSHUFB        Y14, Y5, Y5
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFB        Y15, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFB        Y15, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPADDQ         Y1, Y1, Y9
+	VPSRLQ         $0x3f, Y1, Y1
+	VPXOR          Y9, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPADDQ         Y5, Y5, Y9
+	VPSRLQ         $0x3f, Y5, Y5
+	VPXOR          Y9, Y5, Y5
+	VPERMQ         $0x93, Y1, Y1
+	VPERMQ         $0x4e, Y2, Y2
+	VPERMQ         $0x39, Y3, Y3
+	VPERMQ         $0x93, Y5, Y5
+	VPERMQ         $0x4e, Y6, Y6
+	VPERMQ         $0x39, Y7, Y7
+	VMOVDQU        Y0, 512(AX)
+	VMOVDQU        Y1, 544(AX)
+	VMOVDQU        Y2, 576(AX)
+	VMOVDQU        Y3, 608(AX)
+	VMOVDQU        Y4, 640(AX)
+	VMOVDQU        Y5, 672(AX)
+	VMOVDQU        Y6, 704(AX)
+	VMOVDQU        Y7, 736(AX)
+	VMOVDQU        768(AX), Y0
+	VMOVDQU        800(AX), Y1
+	VMOVDQU        832(AX), Y2
+	VMOVDQU        864(AX), Y3
+	VMOVDQU        896(AX), Y4
+	VMOVDQU        928(AX), Y5
+	VMOVDQU        960(AX), Y6
+	VMOVDQU        992(AX), Y7
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFD        $0xb1, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFD        $0xb1, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPSHUFB        Y14, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPSHUFB        Y14, Y5, Y5
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFB        Y15, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFB        Y15, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPADDQ         Y1, Y1, Y9
+	VPSRLQ         $0x3f, Y1, Y1
+	VPXOR          Y9, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPADDQ         Y5, Y5, Y9
+	VPSRLQ         $0x3f, Y5, Y5
+	VPXOR          Y9, Y5, Y5
+	VPERMQ         $0x39, Y1, Y1
+	VPERMQ         $0x4e, Y2, Y2
+	VPERMQ         $0x93, Y3, Y3
+	VPERMQ         $0x39, Y5, Y5
+	VPERMQ         $0x4e, Y6, Y6
+	VPERMQ         $0x93, Y7, Y7
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFD        $0xb1, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFD        $0xb1, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPSHUFB        Y14, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPSHUFB        Y14, Y5, Y5
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFB        Y15, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFB        Y15, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPADDQ         Y1, Y1, Y9
+	VPSRLQ         $0x3f, Y1, Y1
+	VPXOR          Y9, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPADDQ         Y5, Y5, Y9
+	VPSRLQ         $0x3f, Y5, Y5
+	VPXOR          Y9, Y5, Y5
+	VPERMQ         $0x93, Y1, Y1
+	VPERMQ         $0x4e, Y2, Y2
+	VPERMQ         $0x39, Y3, Y3
+	VPERMQ         $0x93, Y5, Y5
+	VPERMQ         $0x4e, Y6, Y6
+	VPERMQ         $0x39, Y7, Y7
+	VMOVDQU        Y0, 768(AX)
+	VMOVDQU        Y1, 800(AX)
+	VMOVDQU        Y2, 832(AX)
+	VMOVDQU        Y3, 864(AX)
+	VMOVDQU        Y4, 896(AX)
+	VMOVDQU        Y5, 928(AX)
+	VMOVDQU        Y6, 960(AX)
+	VMOVDQU        Y7, 992(AX)
+	VMOVDQU        (AX), X0
+	VINSERTI128    $0x01, 128(AX), Y0, Y0
+	VMOVDQU        256(AX), X1
+	VINSERTI128    $0x01, 384(AX), Y1, Y1
+	VMOVDQU        512(AX), X2
+	VINSERTI128    $0x01, 640(AX), Y2, Y2
+	VMOVDQU        768(AX), X3
+	VINSERTI128    $0x01, 896(AX), Y3, Y3
+	VMOVDQU        16(AX), X4
+	VINSERTI128    $0x01, 144(AX), Y4, Y4
+	VMOVDQU        272(AX), X5
+	VINSERTI128    $0x01, 400(AX), Y5, Y5
+	VMOVDQU        528(AX), X6
+	VINSERTI128    $0x01, 656(AX), Y6, Y6
+	VMOVDQU        784(AX), X7
+	VINSERTI128    $0x01, 912(AX), Y7, Y7
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFD        $0xb1, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFD        $0xb1, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPSHUFB        Y14, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPSHUFB        Y14, Y5, Y5
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFB        Y15, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFB        Y15, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPADDQ         Y1, Y1, Y9
+	VPSRLQ         $0x3f, Y1, Y1
+	VPXOR          Y9, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPADDQ         Y5, Y5, Y9
+	VPSRLQ         $0x3f, Y5, Y5
+	VPXOR          Y9, Y5, Y5
+	VPERMQ         $0x39, Y1, Y1
+	VPERMQ         $0x4e, Y2, Y2
+	VPERMQ         $0x93, Y3, Y3
+	VPERMQ         $0x39, Y5, Y5
+	VPERMQ         $0x4e, Y6, Y6
+	VPERMQ         $0x93, Y7, Y7
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFD        $0xb1, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFD        $0xb1, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPSHUFB        Y14, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPSHUFB        Y14, Y5, Y5
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFB        Y15, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFB        Y15, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPADDQ         Y1, Y1, Y9
+	VPSRLQ         $0x3f, Y1, Y1
+	VPXOR          Y9, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPADDQ         Y5, Y5, Y9
+	VPSRLQ         $0x3f, Y5, Y5
+	VPXOR          Y9, Y5, Y5
+	VPERMQ         $0x93, Y1, Y1
+	VPERMQ         $0x4e, Y2, Y2
+	VPERMQ         $0x39, Y3, Y3
+	VPERMQ         $0x93, Y5, Y5
+	VPERMQ         $0x4e, Y6, Y6
+	VPERMQ         $0x39, Y7, Y7
+	VMOVDQU        X0, (AX)
+	VEXTRACTI128   $0x01, Y0, 128(AX)
+	VMOVDQU        X1, 256(AX)
+	VEXTRACTI128   $0x01, Y1, 384(AX)
+	VMOVDQU        X2, 512(AX)
+	VEXTRACTI128   $0x01, Y2, 640(AX)
+	VMOVDQU        X3, 768(AX)
+	VEXTRACTI128   $0x01, Y3, 896(AX)
+	VMOVDQU        X4, 16(AX)
+	VEXTRACTI128   $0x01, Y4, 144(AX)
+	VMOVDQU        X5, 272(AX)
+	VEXTRACTI128   $0x01, Y5, 400(AX)
+	VMOVDQU        X6, 528(AX)
+	VEXTRACTI128   $0x01, Y6, 656(AX)
+	VMOVDQU        X7, 784(AX)
+	VEXTRACTI128   $0x01, Y7, 912(AX)
+	VMOVDQU        32(AX), X0
+	VINSERTI128    $0x01, 160(AX), Y0, Y0
+	VMOVDQU        288(AX), X1
+	VINSERTI128    $0x01, 416(AX), Y1, Y1
+	VMOVDQU        544(AX), X2
+	VINSERTI128    $0x01, 672(AX), Y2, Y2
+	VMOVDQU        800(AX), X3
+	VINSERTI128    $0x01, 928(AX), Y3, Y3
+	VMOVDQU        48(AX), X4
+	VINSERTI128    $0x01, 176(AX), Y4, Y4
+	VMOVDQU        304(AX), X5
+	VINSERTI128    $0x01, 432(AX), Y5, Y5
+	VMOVDQU        560(AX), X6
+	VINSERTI128    $0x01, 688(AX), Y6, Y6
+	VMOVDQU        816(AX), X7
+	VINSERTI128    $0x01, 944(AX), Y7, Y7
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFD        $0xb1, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFD        $0xb1, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPSHUFB        Y14, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPSHUFB        Y14, Y5, Y5
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFB        Y15, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFB        Y15, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPADDQ         Y1, Y1, Y9
+	VPSRLQ         $0x3f, Y1, Y1
+	VPXOR          Y9, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPADDQ         Y5, Y5, Y9
+	VPSRLQ         $0x3f, Y5, Y5
+	VPXOR          Y9, Y5, Y5
+	VPERMQ         $0x39, Y1, Y1
+	VPERMQ         $0x4e, Y2, Y2
+	VPERMQ         $0x93, Y3, Y3
+	VPERMQ         $0x39, Y5, Y5
+	VPERMQ         $0x4e, Y6, Y6
+	VPERMQ         $0x93, Y7, Y7
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFD        $0xb1, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFD        $0xb1, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPSHUFB        Y14, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPSHUFB        Y14, Y5, Y5
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFB        Y15, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFB        Y15, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPADDQ         Y1, Y1, Y9
+	VPSRLQ         $0x3f, Y1, Y1
+	VPXOR          Y9, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPADDQ         Y5, Y5, Y9
+	VPSRLQ         $0x3f, Y5, Y5
+	VPXOR          Y9, Y5, Y5
+	VPERMQ         $0x93, Y1, Y1
+	VPERMQ         $0x4e, Y2, Y2
+	VPERMQ         $0x39, Y3, Y3
+	VPERMQ         $0x93, Y5, Y5
+	VPERMQ         $0x4e, Y6, Y6
+	VPERMQ         $0x39, Y7, Y7
+	VMOVDQU        X0, 32(AX)
+	VEXTRACTI128   $0x01, Y0, 160(AX)
+	VMOVDQU        X1, 288(AX)
+	VEXTRACTI128   $0x01, Y1, 416(AX)
+	VMOVDQU        X2, 544(AX)
+	VEXTRACTI128   $0x01, Y2, 672(AX)
+	VMOVDQU        X3, 800(AX)
+	VEXTRACTI128   $0x01, Y3, 928(AX)
+	VMOVDQU        X4, 48(AX)
+	VEXTRACTI128   $0x01, Y4, 176(AX)
+	VMOVDQU        X5, 304(AX)
+	VEXTRACTI128   $0x01, Y5, 432(AX)
+	VMOVDQU        X6, 560(AX)
+	VEXTRACTI128   $0x01, Y6, 688(AX)
+	VMOVDQU        X7, 816(AX)
+	VEXTRACTI128   $0x01, Y7, 944(AX)
+	VMOVDQU        64(AX), X0
+	VINSERTI128    $0x01, 192(AX), Y0, Y0
+	VMOVDQU        320(AX), X1
+	VINSERTI128    $0x01, 448(AX), Y1, Y1
+	VMOVDQU        576(AX), X2
+	VINSERTI128    $0x01, 704(AX), Y2, Y2
+	VMOVDQU        832(AX), X3
+	VINSERTI128    $0x01, 960(AX), Y3, Y3
+	VMOVDQU        80(AX), X4
+	VINSERTI128    $0x01, 208(AX), Y4, Y4
+	VMOVDQU        336(AX), X5
+	VINSERTI128    $0x01, 464(AX), Y5, Y5
+	VMOVDQU        592(AX), X6
+	VINSERTI128    $0x01, 720(AX), Y6, Y6
+	VMOVDQU        848(AX), X7
+	VINSERTI128    $0x01, 976(AX), Y7, Y7
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFD        $0xb1, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFD        $0xb1, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPSHUFB        Y14, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPSHUFB        Y14, Y5, Y5
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFB        Y15, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFB        Y15, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPADDQ         Y1, Y1, Y9
+	VPSRLQ         $0x3f, Y1, Y1
+	VPXOR          Y9, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPADDQ         Y5, Y5, Y9
+	VPSRLQ         $0x3f, Y5, Y5
+	VPXOR          Y9, Y5, Y5
+	VPERMQ         $0x39, Y1, Y1
+	VPERMQ         $0x4e, Y2, Y2
+	VPERMQ         $0x93, Y3, Y3
+	VPERMQ         $0x39, Y5, Y5
+	VPERMQ         $0x4e, Y6, Y6
+	VPERMQ         $0x93, Y7, Y7
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFD        $0xb1, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFD        $0xb1, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPSHUFB        Y14, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPSHUFB        Y14, Y5, Y5
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFB        Y15, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFB        Y15, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPADDQ         Y1, Y1, Y9
+	VPSRLQ         $0x3f, Y1, Y1
+	VPXOR          Y9, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPADDQ         Y5, Y5, Y9
+	VPSRLQ         $0x3f, Y5, Y5
+	VPXOR          Y9, Y5, Y5
+	VPERMQ         $0x93, Y1, Y1
+	VPERMQ         $0x4e, Y2, Y2
+	VPERMQ         $0x39, Y3, Y3
+	VPERMQ         $0x93, Y5, Y5
+	VPERMQ         $0x4e, Y6, Y6
+	VPERMQ         $0x39, Y7, Y7
+	VMOVDQU        X0, 64(AX)
+	VEXTRACTI128   $0x01, Y0, 192(AX)
+	VMOVDQU        X1, 320(AX)
+	VEXTRACTI128   $0x01, Y1, 448(AX)
+	VMOVDQU        X2, 576(AX)
+	VEXTRACTI128   $0x01, Y2, 704(AX)
+	VMOVDQU        X3, 832(AX)
+	VEXTRACTI128   $0x01, Y3, 960(AX)
+	VMOVDQU        X4, 80(AX)
+	VEXTRACTI128   $0x01, Y4, 208(AX)
+	VMOVDQU        X5, 336(AX)
+	VEXTRACTI128   $0x01, Y5, 464(AX)
+	VMOVDQU        X6, 592(AX)
+	VEXTRACTI128   $0x01, Y6, 720(AX)
+	VMOVDQU        X7, 848(AX)
+	VEXTRACTI128   $0x01, Y7, 976(AX)
+	VMOVDQU        96(AX), X0
+	VINSERTI128    $0x01, 224(AX), Y0, Y0
+	VMOVDQU        352(AX), X1
+	VINSERTI128    $0x01, 480(AX), Y1, Y1
+	VMOVDQU        608(AX), X2
+	VINSERTI128    $0x01, 736(AX), Y2, Y2
+	VMOVDQU        864(AX), X3
+	VINSERTI128    $0x01, 992(AX), Y3, Y3
+	VMOVDQU        112(AX), X4
+	VINSERTI128    $0x01, 240(AX), Y4, Y4
+	VMOVDQU        368(AX), X5
+	VINSERTI128    $0x01, 496(AX), Y5, Y5
+	VMOVDQU        624(AX), X6
+	VINSERTI128    $0x01, 752(AX), Y6, Y6
+	VMOVDQU        880(AX), X7
+	VINSERTI128    $0x01, 1008(AX), Y7, Y7
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFD        $0xb1, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFD        $0xb1, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPSHUFB        Y14, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPSHUFB        Y14, Y5, Y5
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFB        Y15, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFB        Y15, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPADDQ         Y1, Y1, Y9
+	VPSRLQ         $0x3f, Y1, Y1
+	VPXOR          Y9, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPADDQ         Y5, Y5, Y9
+	VPSRLQ         $0x3f, Y5, Y5
+	VPXOR          Y9, Y5, Y5
+	VPERMQ         $0x39, Y1, Y1
+	VPERMQ         $0x4e, Y2, Y2
+	VPERMQ         $0x93, Y3, Y3
+	VPERMQ         $0x39, Y5, Y5
+	VPERMQ         $0x4e, Y6, Y6
+	VPERMQ         $0x93, Y7, Y7
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFD        $0xb1, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFD        $0xb1, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPSHUFB        Y14, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPSHUFB        Y14, Y5, Y5
+	VPMULUDQ       Y1, Y0, Y8
+	VPADDQ         Y1, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPADDQ         Y8, Y0, Y0
+	VPXOR          Y0, Y3, Y3
+	VPSHUFB        Y15, Y3, Y3
+	VPMULUDQ       Y5, Y4, Y8
+	VPADDQ         Y5, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPADDQ         Y8, Y4, Y4
+	VPXOR          Y4, Y7, Y7
+	VPSHUFB        Y15, Y7, Y7
+	VPMULUDQ       Y3, Y2, Y8
+	VPADDQ         Y3, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPADDQ         Y8, Y2, Y2
+	VPXOR          Y2, Y1, Y1
+	VPADDQ         Y1, Y1, Y9
+	VPSRLQ         $0x3f, Y1, Y1
+	VPXOR          Y9, Y1, Y1
+	VPMULUDQ       Y7, Y6, Y8
+	VPADDQ         Y7, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPADDQ         Y8, Y6, Y6
+	VPXOR          Y6, Y5, Y5
+	VPADDQ         Y5, Y5, Y9
+	VPSRLQ         $0x3f, Y5, Y5
+	VPXOR          Y9, Y5, Y5
+	VPERMQ         $0x93, Y1, Y1
+	VPERMQ         $0x4e, Y2, Y2
+	VPERMQ         $0x39, Y3, Y3
+	VPERMQ         $0x93, Y5, Y5
+	VPERMQ         $0x4e, Y6, Y6
+	VPERMQ         $0x39, Y7, Y7
+	VMOVDQU        X0, 96(AX)
+	VEXTRACTI128   $0x01, Y0, 224(AX)
+	VMOVDQU        X1, 352(AX)
+	VEXTRACTI128   $0x01, Y1, 480(AX)
+	VMOVDQU        X2, 608(AX)
+	VEXTRACTI128   $0x01, Y2, 736(AX)
+	VMOVDQU        X3, 864(AX)
+	VEXTRACTI128   $0x01, Y3, 992(AX)
+	VMOVDQU        X4, 112(AX)
+	VEXTRACTI128   $0x01, Y4, 240(AX)
+	VMOVDQU        X5, 368(AX)
+	VEXTRACTI128   $0x01, Y5, 496(AX)
+	VMOVDQU        X6, 624(AX)
+	VEXTRACTI128   $0x01, Y6, 752(AX)
+	VMOVDQU        X7, 880(AX)
+	VEXTRACTI128   $0x01, Y7, 1008(AX)
+	VZEROUPPER
+	RET
+
+// func blamkaAVX512(b *block)
+// Requires: AVX, AVX2, AVX512F, AVX512VL
+TEXT ·blamkaAVX512(SB), NOSPLIT, $0-8
+	MOVQ         b+0(FP), AX
+	VMOVDQU      (AX), Y0
+	VMOVDQU      32(AX), Y1
+	VMOVDQU      64(AX), Y2
+	VMOVDQU      96(AX), Y3
+	VMOVDQU      128(AX), Y4
+	VMOVDQU      160(AX), Y5
+	VMOVDQU      192(AX), Y6
+	VMOVDQU      224(AX), Y7
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x20, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x20, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x18, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x18, Y5, Y5
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x10, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x10, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x3f, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x3f, Y5, Y5
+	VPERMQ       $0x39, Y1, Y1
+	VPERMQ       $0x4e, Y2, Y2
+	VPERMQ       $0x93, Y3, Y3
+	VPERMQ       $0x39, Y5, Y5
+	VPERMQ       $0x4e, Y6, Y6
+	VPERMQ       $0x93, Y7, Y7
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x20, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x20, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x18, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x18, Y5, Y5
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x10, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x10, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x3f, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x3f, Y5, Y5
+	VPERMQ       $0x93, Y1, Y1
+	VPERMQ       $0x4e, Y2, Y2
+	VPERMQ       $0x39, Y3, Y3
+	VPERMQ       $0x93, Y5, Y5
+	VPERMQ       $0x4e, Y6, Y6
+	VPERMQ       $0x39, Y7, Y7
+	VMOVDQU      Y0, (AX)
+	VMOVDQU      Y1, 32(AX)
+	VMOVDQU      Y2, 64(AX)
+	VMOVDQU      Y3, 96(AX)
+	VMOVDQU      Y4, 128(AX)
+	VMOVDQU      Y5, 160(AX)
+	VMOVDQU      Y6, 192(AX)
+	VMOVDQU      Y7, 224(AX)
+	VMOVDQU      256(AX), Y0
+	VMOVDQU      288(AX), Y1
+	VMOVDQU      320(AX), Y2
+	VMOVDQU      352(AX), Y3
+	VMOVDQU      384(AX), Y4
+	VMOVDQU      416(AX), Y5
+	VMOVDQU      448(AX), Y6
+	VMOVDQU      480(AX), Y7
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x20, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x20, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x18, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x18, Y5, Y5
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x10, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x10, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x3f, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x3f, Y5, Y5
+	VPERMQ       $0x39, Y1, Y1
+	VPERMQ       $0x4e, Y2, Y2
+	VPERMQ       $0x93, Y3, Y3
+	VPERMQ       $0x39, Y5, Y5
+	VPERMQ       $0x4e, Y6, Y6
+	VPERMQ       $0x93, Y7, Y7
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x20, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x20, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x18, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x18, Y5, Y5
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x10, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x10, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x3f, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x3f, Y5, Y5
+	VPERMQ       $0x93, Y1, Y1
+	VPERMQ       $0x4e, Y2, Y2
+	VPERMQ       $0x39, Y3, Y3
+	VPERMQ       $0x93, Y5, Y5
+	VPERMQ       $0x4e, Y6, Y6
+	VPERMQ       $0x39, Y7, Y7
+	VMOVDQU      Y0, 256(AX)
+	VMOVDQU      Y1, 288(AX)
+	VMOVDQU      Y2, 320(AX)
+	VMOVDQU      Y3, 352(AX)
+	VMOVDQU      Y4, 384(AX)
+	VMOVDQU      Y5, 416(AX)
+	VMOVDQU      Y6, 448(AX)
+	VMOVDQU      Y7, 480(AX)
+	VMOVDQU      512(AX), Y0
+	VMOVDQU      544(AX), Y1
+	VMOVDQU      576(AX), Y2
+	VMOVDQU      608(AX), Y3
+	VMOVDQU      640(AX), Y4
+	VMOVDQU      672(AX), Y5
+	VMOVDQU      704(AX), Y6
+	VMOVDQU      736(AX), Y7
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x20, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x20, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x18, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x18, Y5, Y5
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x10, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x10, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x3f, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x3f, Y5, Y5
+	VPERMQ       $0x39, Y1, Y1
+	VPERMQ       $0x4e, Y2, Y2
+	VPERMQ       $0x93, Y3, Y3
+	VPERMQ       $0x39, Y5, Y5
+	VPERMQ       $0x4e, Y6, Y6
+	VPERMQ       $0x93, Y7, Y7
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x20, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x20, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x18, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x18, Y5, Y5
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x10, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x10, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x3f, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x3f, Y5, Y5
+	VPERMQ       $0x93, Y1, Y1
+	VPERMQ       $0x4e, Y2, Y2
+	VPERMQ       $0x39, Y3, Y3
+	VPERMQ       $0x93, Y5, Y5
+	VPERMQ       $0x4e, Y6, Y6
+	VPERMQ       $0x39, Y7, Y7
+	VMOVDQU      Y0, 512(AX)
+	VMOVDQU      Y1, 544(AX)
+	VMOVDQU      Y2, 576(AX)
+	VMOVDQU      Y3, 608(AX)
+	VMOVDQU      Y4, 640(AX)
+	VMOVDQU      Y5, 672(AX)
+	VMOVDQU      Y6, 704(AX)
+	VMOVDQU      Y7, 736(AX)
+	VMOVDQU      768(AX), Y0
+	VMOVDQU      800(AX), Y1
+	VMOVDQU      832(AX), Y2
+	VMOVDQU      864(AX), Y3
+	VMOVDQU      896(AX), Y4
+	VMOVDQU      928(AX), Y5
+	VMOVDQU      960(AX), Y6
+	VMOVDQU      992(AX), Y7
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x20, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x20, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x18, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x18, Y5, Y5
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x10, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x10, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x3f, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x3f, Y5, Y5
+	VPERMQ       $0x39, Y1, Y1
+	VPERMQ       $0x4e, Y2, Y2
+	VPERMQ       $0x93, Y3, Y3
+	VPERMQ       $0x39, Y5, Y5
+	VPERMQ       $0x4e, Y6, Y6
+	VPERMQ       $0x93, Y7, Y7
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x20, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x20, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x18, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x18, Y5, Y5
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x10, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x10, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x3f, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x3f, Y5, Y5
+	VPERMQ       $0x93, Y1, Y1
+	VPERMQ       $0x4e, Y2, Y2
+	VPERMQ       $0x39, Y3, Y3
+	VPERMQ       $0x93, Y5, Y5
+	VPERMQ       $0x4e, Y6, Y6
+	VPERMQ       $0x39, Y7, Y7
+	VMOVDQU      Y0, 768(AX)
+	VMOVDQU      Y1, 800(AX)
+	VMOVDQU      Y2, 832(AX)
+	VMOVDQU      Y3, 864(AX)
+	VMOVDQU      Y4, 896(AX)
+	VMOVDQU      Y5, 928(AX)
+	VMOVDQU      Y6, 960(AX)
+	VMOVDQU      Y7, 992(AX)
+	VMOVDQU      (AX), X0
+	VINSERTI128  $0x01, 128(AX), Y0, Y0
+	VMOVDQU      256(AX), X1
+	VINSERTI128  $0x01, 384(AX), Y1, Y1
+	VMOVDQU      512(AX), X2
+	VINSERTI128  $0x01, 640(AX), Y2, Y2
+	VMOVDQU      768(AX), X3
+	VINSERTI128  $0x01, 896(AX), Y3, Y3
+	VMOVDQU      16(AX), X4
+	VINSERTI128  $0x01, 144(AX), Y4, Y4
+	VMOVDQU      272(AX), X5
+	VINSERTI128  $0x01, 400(AX), Y5, Y5
+	VMOVDQU      528(AX), X6
+	VINSERTI128  $0x01, 656(AX), Y6, Y6
+	VMOVDQU      784(AX), X7
+	VINSERTI128  $0x01, 912(AX), Y7, Y7
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x20, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x20, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x18, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x18, Y5, Y5
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x10, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x10, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x3f, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x3f, Y5, Y5
+	VPERMQ       $0x39, Y1, Y1
+	VPERMQ       $0x4e, Y2, Y2
+	VPERMQ       $0x93, Y3, Y3
+	VPERMQ       $0x39, Y5, Y5
+	VPERMQ       $0x4e, Y6, Y6
+	VPERMQ       $0x93, Y7, Y7
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x20, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x20, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x18, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x18, Y5, Y5
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x10, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x10, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x3f, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x3f, Y5, Y5
+	VPERMQ       $0x93, Y1, Y1
+	VPERMQ       $0x4e, Y2, Y2
+	VPERMQ       $0x39, Y3, Y3
+	VPERMQ       $0x93, Y5, Y5
+	VPERMQ       $0x4e, Y6, Y6
+	VPERMQ       $0x39, Y7, Y7
+	VMOVDQU      X0, (AX)
+	VEXTRACTI128 $0x01, Y0, 128(AX)
+	VMOVDQU      X1, 256(AX)
+	VEXTRACTI128 $0x01, Y1, 384(AX)
+	VMOVDQU      X2, 512(AX)
+	VEXTRACTI128 $0x01, Y2, 640(AX)
+	VMOVDQU      X3, 768(AX)
+	VEXTRACTI128 $0x01, Y3, 896(AX)
+	VMOVDQU      X4, 16(AX)
+	VEXTRACTI128 $0x01, Y4, 144(AX)
+	VMOVDQU      X5, 272(AX)
+	VEXTRACTI128 $0x01, Y5, 400(AX)
+	VMOVDQU      X6, 528(AX)
+	VEXTRACTI128 $0x01, Y6, 656(AX)
+	VMOVDQU      X7, 784(AX)
+	VEXTRACTI128 $0x01, Y7, 912(AX)
+	VMOVDQU      32(AX), X0
+	VINSERTI128  $0x01, 160(AX), Y0, Y0
+	VMOVDQU      288(AX), X1
+	VINSERTI128  $0x01, 416(AX), Y1, Y1
+	VMOVDQU      544(AX), X2
+	VINSERTI128  $0x01, 672(AX), Y2, Y2
+	VMOVDQU      800(AX), X3
+	VINSERTI128  $0x01, 928(AX), Y3, Y3
+	VMOVDQU      48(AX), X4
+	VINSERTI128  $0x01, 176(AX), Y4, Y4
+	VMOVDQU      304(AX), X5
+	VINSERTI128  $0x01, 432(AX), Y5, Y5
+	VMOVDQU      560(AX), X6
+	VINSERTI128  $0x01, 688(AX), Y6, Y6
+	VMOVDQU      816(AX), X7
+	VINSERTI128  $0x01, 944(AX), Y7, Y7
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x20, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x20, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x18, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x18, Y5, Y5
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x10, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x10, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x3f, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x3f, Y5, Y5
+	VPERMQ       $0x39, Y1, Y1
+	VPERMQ       $0x4e, Y2, Y2
+	VPERMQ       $0x93, Y3, Y3
+	VPERMQ       $0x39, Y5, Y5
+	VPERMQ       $0x4e, Y6, Y6
+	VPERMQ       $0x93, Y7, Y7
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x20, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x20, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x18, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x18, Y5, Y5
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x10, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x10, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x3f, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x3f, Y5, Y5
+	VPERMQ       $0x93, Y1, Y1
+	VPERMQ       $0x4e, Y2, Y2
+	VPERMQ       $0x39, Y3, Y3
+	VPERMQ       $0x93, Y5, Y5
+	VPERMQ       $0x4e, Y6, Y6
+	VPERMQ       $0x39, Y7, Y7
+	VMOVDQU      X0, 32(AX)
+	VEXTRACTI128 $0x01, Y0, 160(AX)
+	VMOVDQU      X1, 288(AX)
+	VEXTRACTI128 $0x01, Y1, 416(AX)
+	VMOVDQU      X2, 544(AX)
+	VEXTRACTI128 $0x01, Y2, 672(AX)
+	VMOVDQU      X3, 800(AX)
+	VEXTRACTI128 $0x01, Y3, 928(AX)
+	VMOVDQU      X4, 48(AX)
+	VEXTRACTI128 $0x01, Y4, 176(AX)
+	VMOVDQU      X5, 304(AX)
+	VEXTRACTI128 $0x01, Y5, 432(AX)
+	VMOVDQU      X6, 560(AX)
+	VEXTRACTI128 $0x01, Y6, 688(AX)
+	VMOVDQU      X7, 816(AX)
+	VEXTRACTI128 $0x01, Y7, 944(AX)
+	VMOVDQU      64(AX), X0
+	VINSERTI128  $0x01, 192(AX), Y0, Y0
+	VMOVDQU      320(AX), X1
+	VINSERTI128  $0x01, 448(AX), Y1, Y1
+	VMOVDQU      576(AX), X2
+	VINSERTI128  $0x01, 704(AX), Y2, Y2
+	VMOVDQU      832(AX), X3
+	VINSERTI128  $0x01, 960(AX), Y3, Y3
+	VMOVDQU      80(AX), X4
+	VINSERTI128  $0x01, 208(AX), Y4, Y4
+	VMOVDQU      336(AX), X5
+	VINSERTI128  $0x01, 464(AX), Y5, Y5
+	VMOVDQU      592(AX), X6
+	VINSERTI128  $0x01, 720(AX), Y6, Y6
+	VMOVDQU      848(AX), X7
+	VINSERTI128  $0x01, 976(AX), Y7, Y7
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x20, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x20, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x18, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x18, Y5, Y5
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x10, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x10, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x3f, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x3f, Y5, Y5
+	VPERMQ       $0x39, Y1, Y1
+	VPERMQ       $0x4e, Y2, Y2
+	VPERMQ       $0x93, Y3, Y3
+	VPERMQ       $0x39, Y5, Y5
+	VPERMQ       $0x4e, Y6, Y6
+	VPERMQ       $0x93, Y7, Y7
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x20, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x20, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x18, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x18, Y5, Y5
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x10, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x10, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x3f, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x3f, Y5, Y5
+	VPERMQ       $0x93, Y1, Y1
+	VPERMQ       $0x4e, Y2, Y2
+	VPERMQ       $0x39, Y3, Y3
+	VPERMQ       $0x93, Y5, Y5
+	VPERMQ       $0x4e, Y6, Y6
+	VPERMQ       $0x39, Y7, Y7
+	VMOVDQU      X0, 64(AX)
+	VEXTRACTI128 $0x01, Y0, 192(AX)
+	VMOVDQU      X1, 320(AX)
+	VEXTRACTI128 $0x01, Y1, 448(AX)
+	VMOVDQU      X2, 576(AX)
+	VEXTRACTI128 $0x01, Y2, 704(AX)
+	VMOVDQU      X3, 832(AX)
+	VEXTRACTI128 $0x01, Y3, 960(AX)
+	VMOVDQU      X4, 80(AX)
+	VEXTRACTI128 $0x01, Y4, 208(AX)
+	VMOVDQU      X5, 336(AX)
+	VEXTRACTI128 $0x01, Y5, 464(AX)
+	VMOVDQU      X6, 592(AX)
+	VEXTRACTI128 $0x01, Y6, 720(AX)
+	VMOVDQU      X7, 848(AX)
+	VEXTRACTI128 $0x01, Y7, 976(AX)
+	VMOVDQU      96(AX), X0
+	VINSERTI128  $0x01, 224(AX), Y0, Y0
+	VMOVDQU      352(AX), X1
+	VINSERTI128  $0x01, 480(AX), Y1, Y1
+	VMOVDQU      608(AX), X2
+	VINSERTI128  $0x01, 736(AX), Y2, Y2
+	VMOVDQU      864(AX), X3
+	VINSERTI128  $0x01, 992(AX), Y3, Y3
+	VMOVDQU      112(AX), X4
+	VINSERTI128  $0x01, 240(AX), Y4, Y4
+	VMOVDQU      368(AX), X5
+	VINSERTI128  $0x01, 496(AX), Y5, Y5
+	VMOVDQU      624(AX), X6
+	VINSERTI128  $0x01, 752(AX), Y6, Y6
+	VMOVDQU      880(AX), X7
+	VINSERTI128  $0x01, 1008(AX), Y7, Y7
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x20, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x20, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x18, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x18, Y5, Y5
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x10, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x10, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x3f, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x3f, Y5, Y5
+	VPERMQ       $0x39, Y1, Y1
+	VPERMQ       $0x4e, Y2, Y2
+	VPERMQ       $0x93, Y3, Y3
+	VPERMQ       $0x39, Y5, Y5
+	VPERMQ       $0x4e, Y6, Y6
+	VPERMQ       $0x93, Y7, Y7
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x20, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x20, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x18, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x18, Y5, Y5
+	VPMULUDQ     Y1, Y0, Y8
+	VPADDQ       Y1, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPADDQ       Y8, Y0, Y0
+	VPXOR        Y0, Y3, Y3
+	VPRORQ       $0x10, Y3, Y3
+	VPMULUDQ     Y5, Y4, Y8
+	VPADDQ       Y5, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPADDQ       Y8, Y4, Y4
+	VPXOR        Y4, Y7, Y7
+	VPRORQ       $0x10, Y7, Y7
+	VPMULUDQ     Y3, Y2, Y8
+	VPADDQ       Y3, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPADDQ       Y8, Y2, Y2
+	VPXOR        Y2, Y1, Y1
+	VPRORQ       $0x3f, Y1, Y1
+	VPMULUDQ     Y7, Y6, Y8
+	VPADDQ       Y7, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPADDQ       Y8, Y6, Y6
+	VPXOR        Y6, Y5, Y5
+	VPRORQ       $0x3f, Y5, Y5
+	VPERMQ       $0x93, Y1, Y1
+	VPERMQ       $0x4e, Y2, Y2
+	VPERMQ       $0x39, Y3, Y3
+	VPERMQ       $0x93, Y5, Y5
+	VPERMQ       $0x4e, Y6, Y6
+	VPERMQ       $0x39, Y7, Y7
+	VMOVDQU      X0, 96(AX)
+	VEXTRACTI128 $0x01, Y0, 224(AX)
+	VMOVDQU      X1, 352(AX)
+	VEXTRACTI128 $0x01, Y1, 480(AX)
+	VMOVDQU      X2, 608(AX)
+	VEXTRACTI128 $0x01, Y2, 736(AX)
+	VMOVDQU      X3, 864(AX)
+	VEXTRACTI128 $0x01, Y3, 992(AX)
+	VMOVDQU      X4, 112(AX)
+	VEXTRACTI128 $0x01, Y4, 240(AX)
+	VMOVDQU      X5, 368(AX)
+	VEXTRACTI128 $0x01, Y5, 496(AX)
+	VMOVDQU      X6, 624(AX)
+	VEXTRACTI128 $0x01, Y6, 752(AX)
+	VMOVDQU      X7, 880(AX)
+	VEXTRACTI128 $0x01, Y7, 1008(AX)
+	VZEROUPPER
+	RET
+
 // func mixBlocksSSE2(out *block, a *block, b *block, c *block)
 // Requires: SSE2
 TEXT ·mixBlocksSSE2(SB), NOSPLIT, $0-32
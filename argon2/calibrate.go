@@ -0,0 +1,135 @@
+package argon2
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+// Calibration is the result of Calibrate.
+type Calibration struct {
+	// Params are the strongest parameters found which meet the target duration.
+	Params Params
+
+	// Duration is the time measured for a derivation using Params.
+	Duration time.Duration
+
+	// Timings are the times measured for each derivation made during the calibration, in the order they were made.
+	Timings []Timing
+}
+
+// Timing is the time measured for a derivation with a given number of passes and memory size during calibration.
+type Timing struct {
+	Time, Memory uint32
+	Duration     time.Duration
+}
+
+// Calibrate benchmarks Argon2id on the current machine to find the strongest parameters for which a single derivation
+// takes no longer than target, using at most memory KiB and the given number of threads. It follows the procedure in
+// RFC 9106 Section 4: the memory is preferred over the number of passes, so the memory is only reduced below the
+// ceiling if a single pass takes longer than target, and the number of passes is then increased for as long as the
+// derivation still meets target. The number of passes is found by bisection so the calibration takes a number of
+// derivations logarithmic in it. The returned parameters use a 32 byte key which together with a 16 byte salt is the
+// recommendation of RFC 9106.
+//
+// Each candidate is measured once, so the results are subject to the load on the machine at the time. Calibrate
+// returns a CalibrationTargetError if target cannot be met with the minimum memory and a single pass, and ctx.Err()
+// if ctx is done before the calibration finishes.
+func Calibrate(ctx context.Context, target time.Duration, memory, threads uint32) (*Calibration, error) {
+	params := Params{Time: 1, Memory: memory, Threads: threads, KeyLen: 32}
+
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	c := &Calibration{}
+
+	minimum := MinMemoryPerThread * threads
+	params.Memory = blocks(memory, threads)
+
+	d, err := c.measure(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reduce the memory in proportion to the time over the target until a single pass meets it.
+	for d > target {
+		if params.Memory == minimum {
+			return nil, CalibrationTargetError{Target: target, Measured: d}
+		}
+
+		next := uint32(uint64(params.Memory) * uint64(target) / uint64(d))
+		if next >= params.Memory {
+			next = params.Memory - 1
+		}
+
+		params.Memory = blocks(max(next, minimum), threads)
+
+		if d, err = c.measure(ctx, params); err != nil {
+			return nil, err
+		}
+	}
+
+	c.Params, c.Duration = params, d
+
+	// Search for the highest number of passes which meets the target, starting from the estimate of the time of a
+	// single pass. Until a number of passes is found which does not meet the target the number is doubled, after that
+	// the range between the highest number which does and the lowest number which does not is bisected. As the estimate
+	// may be far off if a single pass is too short to be measured accurately, a derivation is stopped once it takes
+	// twice the target and treated as not meeting it.
+	lo, hi, bounded := uint64(1), uint64(math.MaxUint32)+1, false
+	next := min(max(uint64(target/d), 2), math.MaxUint32)
+	limit := min(target, math.MaxInt64/2) * 2
+
+	for lo+1 < hi {
+		params.Time = uint32(next)
+
+		probe, cancel := context.WithTimeout(ctx, limit)
+		d, err = c.measure(probe, params)
+		cancel()
+
+		if err != nil {
+			if !errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+				return nil, err
+			}
+
+			d = limit
+		}
+
+		if d <= target {
+			lo = next
+			c.Params, c.Duration = params, d
+		} else {
+			hi, bounded = next, true
+		}
+
+		if bounded {
+			next = lo + (hi-lo)/2
+		} else {
+			next = min(2*lo, hi-1)
+		}
+	}
+
+	return c, nil
+}
+
+func (c *Calibration) measure(ctx context.Context, params Params) (time.Duration, error) {
+	var (
+		password = []byte("password")
+		salt     = make([]byte, 16)
+	)
+
+	start := time.Now()
+
+	if _, err := derive(ctx, Argon2id, password, salt, params); err != nil {
+		return 0, err
+	}
+
+	// A coarse timer may measure no time at all for small parameters, which must not be divided by.
+	d := max(time.Since(start), time.Nanosecond)
+
+	c.Timings = append(c.Timings, Timing{Time: params.Time, Memory: params.Memory, Duration: d})
+
+	return d, nil
+}
@@ -0,0 +1,93 @@
+package argon2
+
+import (
+	"context"
+	"errors"
+	"math/bits"
+	"testing"
+	"time"
+)
+
+func TestCalibrate(t *testing.T) {
+	const target = 50 * time.Millisecond
+
+	c, err := Calibrate(t.Context(), target, 64*1024, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err = c.Params.Validate(); err != nil {
+		t.Fatalf("calibrated parameters are not valid: %v", err)
+	}
+
+	if c.Params.Threads != 2 || c.Params.KeyLen != 32 {
+		t.Errorf("expected 2 threads and a 32 byte key but got %d threads and a %d byte key", c.Params.Threads, c.Params.KeyLen)
+	}
+
+	if c.Params.Memory > 64*1024 {
+		t.Errorf("expected memory of at most %d but got %d", 64*1024, c.Params.Memory)
+	}
+
+	if c.Duration > target {
+		t.Errorf("expected a duration of at most %s but got %s", target, c.Duration)
+	}
+
+	if len(c.Timings) == 0 {
+		t.Fatalf("expected the timings of the calibration")
+	}
+
+	var found bool
+
+	for _, timing := range c.Timings {
+		if timing.Time == c.Params.Time && timing.Memory == c.Params.Memory && timing.Duration == c.Duration {
+			found = true
+		}
+
+		// No stronger parameters may have met the target.
+		if timing.Duration <= target && (timing.Memory > c.Params.Memory || timing.Memory == c.Params.Memory && timing.Time > c.Params.Time) {
+			t.Errorf("expected t=%d m=%d measured at %s to be chosen over t=%d m=%d", timing.Time, timing.Memory, timing.Duration, c.Params.Time, c.Params.Memory)
+		}
+	}
+
+	if !found {
+		t.Errorf("expected the timings to include the calibrated parameters")
+	}
+
+	// The number of passes is bisected rather than searched linearly.
+	var passes int
+
+	for _, timing := range c.Timings {
+		if timing.Memory == c.Params.Memory && timing.Time > 1 {
+			passes++
+		}
+	}
+
+	if limit := 2*bits.Len32(c.Params.Time) + 2; passes > limit {
+		t.Errorf("expected at most %d derivations to find %d passes but got %d", limit, c.Params.Time, passes)
+	}
+}
+
+func TestCalibrateErrors(t *testing.T) {
+	if _, err := Calibrate(t.Context(), time.Second, 64*1024, 0); err != InvalidThreadsError(0) {
+		t.Errorf("got err %v but should have given %v", err, InvalidThreadsError(0))
+	}
+
+	if _, err := Calibrate(t.Context(), time.Second, 15, 2); err != (InvalidMemoryError{Memory: 15, Threads: 2}) {
+		t.Errorf("got err %v but should have given %v", err, InvalidMemoryError{Memory: 15, Threads: 2})
+	}
+
+	var target CalibrationTargetError
+
+	if _, err := Calibrate(t.Context(), time.Nanosecond, 1024, 1); !errors.As(err, &target) {
+		t.Errorf("got err %v but should have given a CalibrationTargetError", err)
+	} else if target.Target != time.Nanosecond || target.Measured <= time.Nanosecond {
+		t.Errorf("unexpected durations in %v", target)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	if _, err := Calibrate(ctx, time.Second, 1024, 1); err != context.Canceled {
+		t.Errorf("got err %v but should have given %v", err, context.Canceled)
+	}
+}
@@ -2,14 +2,39 @@ package argon2
 
 const (
 	blockLength = 128
+	blockSize   = blockLength * 8
 	syncPoints  = 4
 )
 
-// Version is the Argon2 version implemented by this package.
-const Version = 0x13
+// Version is the Argon2 version implemented by this package by default.
+const Version = Version13
 
+// The Argon2 versions which can be selected using Params.
 const (
-	argon2d = iota
-	argon2i
-	argon2id
+	Version10 = 0x10 // the legacy Argon2 version 1.0 which overwrites the memory blocks on later passes
+	Version13 = 0x13 // the Argon2 version 1.3 specified by RFC 9106 which XORs the memory blocks on later passes
 )
+
+// The bounds of the Argon2 inputs as specified in RFC 9106 Section 3.1.
+const (
+	MinTime            uint32 = 1         // the minimum number of passes over the memory
+	MinThreads         uint32 = 1         // the minimum degree of parallelism
+	MaxThreads         uint32 = 1<<24 - 1 // the maximum degree of parallelism
+	MinMemoryPerThread uint32 = 8         // the minimum memory size in KiB for each thread
+	MinKeyLength       uint32 = 4         // the minimum length of a derived key in bytes
+	MinSaltLength      uint64 = 8         // the minimum length of a salt in bytes
+	MaxSaltLength      uint64 = 1<<32 - 1 // the maximum length of a salt in bytes
+)
+
+// The Argon2 variants, the values of which are the type (y) specified by RFC 9106 Section 3.2.
+const (
+	Argon2d  Mode = iota // uses data-dependent memory access
+	Argon2i              // uses data-independent memory access
+	Argon2id             // uses data-independent memory access for the first half of the first pass only
+)
+
+var variants = [...]string{
+	Argon2d:  "argon2d",
+	Argon2i:  "argon2i",
+	Argon2id: "argon2id",
+}
@@ -0,0 +1,80 @@
+package argon2
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrMismatchedHashAndPassword is the error returned from Verify when a password and hash do not match.
+	ErrMismatchedHashAndPassword = errors.New("github.com/go-crypt/x/argon2: the provided password is not a match for the provided hashed password")
+
+	// ErrInvalidHash is the error returned from Decode and Verify when a hash is not in the PHC string format.
+	ErrInvalidHash = errors.New("github.com/go-crypt/x/argon2: hashed password is not a valid argon2 PHC string")
+)
+
+// InvalidTimeError is the error returned when the number of passes over the memory is less than MinTime.
+type InvalidTimeError uint32
+
+func (it InvalidTimeError) Error() string {
+	return fmt.Sprintf("github.com/go-crypt/x/argon2: time %d is less than the minimum of %d", uint32(it), MinTime)
+}
+
+// InvalidThreadsError is the error returned when the degree of parallelism is outside the inclusive range
+// MinThreads..MaxThreads.
+type InvalidThreadsError uint32
+
+func (it InvalidThreadsError) Error() string {
+	return fmt.Sprintf("github.com/go-crypt/x/argon2: threads %d is outside allowed inclusive range %d..%d", uint32(it), MinThreads, MaxThreads)
+}
+
+// InvalidMemoryError is the error returned when the memory size is less than MinMemoryPerThread KiB for each thread.
+type InvalidMemoryError struct {
+	Memory  uint32
+	Threads uint32
+}
+
+func (im InvalidMemoryError) Error() string {
+	return fmt.Sprintf("github.com/go-crypt/x/argon2: memory %d KiB is less than the minimum of %d KiB for %d threads", im.Memory, uint64(MinMemoryPerThread)*uint64(im.Threads), im.Threads)
+}
+
+// InvalidKeyLengthError is the error returned when the requested key length is less than MinKeyLength.
+type InvalidKeyLengthError uint32
+
+func (ik InvalidKeyLengthError) Error() string {
+	return fmt.Sprintf("github.com/go-crypt/x/argon2: key length %d is less than the minimum of %d", uint32(ik), MinKeyLength)
+}
+
+// InvalidSaltLengthError is the error returned when the salt length is outside the inclusive range
+// MinSaltLength..MaxSaltLength.
+type InvalidSaltLengthError uint64
+
+func (is InvalidSaltLengthError) Error() string {
+	return fmt.Sprintf("github.com/go-crypt/x/argon2: salt length %d is outside allowed inclusive range %d..%d", uint64(is), MinSaltLength, MaxSaltLength)
+}
+
+// InvalidVersionError is the error returned when the version is neither Version10 nor Version13.
+type InvalidVersionError uint32
+
+func (iv InvalidVersionError) Error() string {
+	return fmt.Sprintf("github.com/go-crypt/x/argon2: version 0x%x is not supported, must be 0x%x or 0x%x", uint32(iv), Version10, Version13)
+}
+
+// CalibrationTargetError is the error returned from Calibrate when a derivation using the minimum memory for the number
+// of threads and a single pass takes longer than the target duration.
+type CalibrationTargetError struct {
+	Target, Measured time.Duration
+}
+
+func (ct CalibrationTargetError) Error() string {
+	return fmt.Sprintf("github.com/go-crypt/x/argon2: target duration %s is less than the %s measured for the minimum parameters", ct.Target, ct.Measured)
+}
+
+// InvalidModeError is the error returned when a Mode is not one of Argon2d, Argon2i, or Argon2id, or a name is not the
+// name of one of them.
+type InvalidModeError string
+
+func (im InvalidModeError) Error() string {
+	return fmt.Sprintf("github.com/go-crypt/x/argon2: mode '%s' is not one of argon2d, argon2i, or argon2id", string(im))
+}
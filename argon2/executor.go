@@ -0,0 +1,71 @@
+package argon2
+
+import (
+	"sync"
+)
+
+// Executor runs the segments of a derivation. Each pass over the memory is split into slices and each slice has one
+// segment per lane, the segments of a slice are handed to the Executor together and must all complete before the next
+// slice starts. The segments of a slice are independent of each other, so an Executor may run them concurrently, one
+// at a time, or synchronously within Go.
+type Executor interface {
+	// Go runs f. It may block until there is capacity to run f.
+	Go(f func())
+}
+
+// Pool is an Executor which runs segments on a fixed number of worker goroutines. A single Pool can be shared by any
+// number of concurrent derivations to cap the CPU used by all of them, without changing the derived keys.
+type Pool struct {
+	tasks chan func()
+	wg    sync.WaitGroup
+	once  sync.Once
+}
+
+// NewPool returns a Pool with the given number of worker goroutines. If workers is less than 1 the Pool has one
+// worker. The Pool must be closed with Close when it is no longer needed.
+func NewPool(workers int) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+
+	p := &Pool{
+		tasks: make(chan func()),
+	}
+
+	p.wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+
+	return p
+}
+
+// Go runs f on the next available worker, blocking until one is available. It panics if the Pool is closed.
+func (p *Pool) Go(f func()) {
+	p.tasks <- f
+}
+
+// Close stops the workers once they have finished the segments they are running. Derivations using the Pool must
+// have completed before it is closed.
+func (p *Pool) Close() {
+	p.once.Do(func() {
+		close(p.tasks)
+		p.wg.Wait()
+	})
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+
+	for f := range p.tasks {
+		f()
+	}
+}
+
+// goExecutor is the default Executor which runs each segment on its own goroutine.
+type goExecutor struct{}
+
+func (goExecutor) Go(f func()) {
+	go f()
+}
@@ -0,0 +1,115 @@
+package argon2
+
+import (
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type syncExecutor struct{}
+
+func (syncExecutor) Go(f func()) { f() }
+
+type countingExecutor struct {
+	Executor
+
+	running, peak atomic.Int32
+}
+
+func (e *countingExecutor) Go(f func()) {
+	e.Executor.Go(func() {
+		running := e.running.Add(1)
+		for {
+			peak := e.peak.Load()
+			if running <= peak || e.peak.CompareAndSwap(peak, running) {
+				break
+			}
+		}
+		f()
+		e.running.Add(-1)
+	})
+}
+
+func TestExecutorVectors(t *testing.T) {
+	pool := NewPool(2)
+	defer pool.Close()
+
+	testCases := []struct {
+		name     string
+		executor Executor
+	}{
+		{"Pool", pool},
+		{"Sync", syncExecutor{}},
+	}
+
+	password, salt := []byte("password"), []byte("somesalt")
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i, v := range testVectors {
+				want, err := hex.DecodeString(v.hash)
+				if err != nil {
+					t.Fatalf("Test %d: failed to decode hash: %v", i, err)
+				}
+
+				hash, err := derive(t.Context(), v.mode, password, salt, Params{Time: v.time, Memory: v.memory, Threads: v.threads, KeyLen: uint32(len(want)), Executor: tc.executor})
+				if err != nil {
+					t.Fatalf("Test %d: unexpected error: %v", i, err)
+				}
+				if got := hex.EncodeToString(hash); got != v.hash {
+					t.Errorf("Test %d - got: %s want: %s", i, got, v.hash)
+				}
+			}
+		})
+	}
+}
+
+func TestPoolBoundsConcurrency(t *testing.T) {
+	const workers = 3
+
+	pool := NewPool(workers)
+	defer pool.Close()
+
+	executor := &countingExecutor{Executor: pool}
+	params := Params{Time: 2, Memory: 1024, Threads: 8, KeyLen: 32, Executor: executor}
+
+	want, err := DeriveIDKey([]byte("password"), []byte("somesalt"), Params{Time: 2, Memory: 1024, Threads: 8, KeyLen: 32})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Go(func() {
+			hash, err := DeriveIDKey([]byte("password"), []byte("somesalt"), params)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if hex.EncodeToString(hash) != hex.EncodeToString(want) {
+				t.Errorf("derived key does not match - got: %x , want: %x", hash, want)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if peak := executor.peak.Load(); peak > workers {
+		t.Errorf("expected at most %d concurrent segments but got %d", workers, peak)
+	}
+}
+
+func TestPoolClose(t *testing.T) {
+	pool := NewPool(0)
+	pool.Close()
+	pool.Close()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected Go to panic on a closed pool")
+		}
+	}()
+
+	pool.Go(func() {})
+}
@@ -0,0 +1,114 @@
+package argon2
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-crypt/x/memlimit"
+)
+
+// Hasher derives keys using a fixed set of Params. Unlike the DeriveIDKey, DeriveIKey, and DeriveDKey functions which
+// allocate the memory for every derivation, a Hasher keeps the memory in a pool and reuses it for later derivations.
+// The memory is zeroed before it is returned to the pool. A Hasher is safe for concurrent use.
+type Hasher struct {
+	params Params
+	blocks uint32
+	pool   sync.Pool
+
+	inUse atomic.Uint64
+	peak  atomic.Uint64
+}
+
+// NewHasher returns a new Hasher for params, or an error if params are invalid as per Params.Validate.
+func NewHasher(params Params) (*Hasher, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	h := &Hasher{
+		params: params,
+		blocks: blocks(params.Memory, params.Threads),
+	}
+
+	h.pool.New = func() any {
+		B := make([]block, h.blocks)
+
+		return &B
+	}
+
+	return h, nil
+}
+
+// Params returns the Params used by the Hasher.
+func (h *Hasher) Params() Params {
+	return h.params
+}
+
+// IDKey derives a key from the password and salt using Argon2id in the same manner as DeriveIDKeyContext.
+func (h *Hasher) IDKey(ctx context.Context, password, salt []byte) ([]byte, error) {
+	return h.derive(ctx, Argon2id, password, salt)
+}
+
+// IKey derives a key from the password and salt using Argon2i in the same manner as DeriveIKeyContext.
+func (h *Hasher) IKey(ctx context.Context, password, salt []byte) ([]byte, error) {
+	return h.derive(ctx, Argon2i, password, salt)
+}
+
+// DKey derives a key from the password and salt using Argon2d in the same manner as DeriveDKeyContext.
+func (h *Hasher) DKey(ctx context.Context, password, salt []byte) ([]byte, error) {
+	return h.derive(ctx, Argon2d, password, salt)
+}
+
+// MemorySize returns the size in bytes of the memory used by a single derivation.
+func (h *Hasher) MemorySize() uint64 {
+	return uint64(h.blocks) * blockSize
+}
+
+// PeakMemory returns the highest number of bytes of memory which were in use by concurrent derivations at once.
+func (h *Hasher) PeakMemory() uint64 {
+	return h.peak.Load()
+}
+
+func (h *Hasher) derive(ctx context.Context, mode Mode, password, salt []byte) ([]byte, error) {
+	if err := checkSalt(salt); err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	release, err := memlimit.Acquire(ctx, h.MemorySize())
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	B := h.get()
+	defer h.put(B)
+
+	return deriveBlocks(ctx, *B, mode, password, salt, h.params)
+}
+
+func (h *Hasher) get() *[]block {
+	size := h.MemorySize()
+	inUse := h.inUse.Add(size)
+
+	for {
+		peak := h.peak.Load()
+		if inUse <= peak || h.peak.CompareAndSwap(peak, inUse) {
+			break
+		}
+	}
+
+	return h.pool.Get().(*[]block)
+}
+
+func (h *Hasher) put(B *[]block) {
+	clear(*B)
+
+	h.pool.Put(B)
+	h.inUse.Add(-h.MemorySize())
+}
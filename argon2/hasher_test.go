@@ -0,0 +1,155 @@
+package argon2
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"sync"
+	"testing"
+)
+
+func TestHasher(t *testing.T) {
+	h, err := NewHasher(Params{Time: 3, Memory: 32, Threads: 4, KeyLen: 32, Secret: genKatSecret, Data: genKatAAD})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name   string
+		derive func(ctx context.Context, password, salt []byte) ([]byte, error)
+		want   string
+	}{
+		{"Argon2d", h.DKey, "512b391b6f1162975371d30919734294f868e3be3984f3c1a13a4db9fabe4acb"},
+		{"Argon2i", h.IKey, "c814d9d1dc7f37aa13f0d77f2494bda1c8de6b016dd388d29952a4c4672b6ce8"},
+		{"Argon2id", h.IDKey, "0d640df58d78766c08c037a34a8b53c9d01ef0452d75b65eb52520e96b01e659"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Derive twice to ensure reused memory produces the same key.
+			for i := 0; i < 2; i++ {
+				hash, err := tc.derive(t.Context(), genKatPassword, genKatSalt)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got := hex.EncodeToString(hash); got != tc.want {
+					t.Errorf("derived key does not match - got: %s , want: %s", got, tc.want)
+				}
+			}
+		})
+	}
+
+	if h.MemorySize() != 32*1024 {
+		t.Errorf("expected memory size of %d but got %d", 32*1024, h.MemorySize())
+	}
+
+	if h.PeakMemory() != h.MemorySize() {
+		t.Errorf("expected peak memory of %d but got %d", h.MemorySize(), h.PeakMemory())
+	}
+
+	B := h.pool.Get().(*[]block)
+	for i := range *B {
+		if (*B)[i] != (block{}) {
+			t.Fatalf("block %d of pooled memory was not zeroed", i)
+		}
+	}
+}
+
+func TestHasherErrors(t *testing.T) {
+	if _, err := NewHasher(Params{Time: 0, Memory: 32, Threads: 4, KeyLen: 32}); err != InvalidTimeError(0) {
+		t.Errorf("got err %v but should have given %v", err, InvalidTimeError(0))
+	}
+
+	h, err := NewHasher(Params{Time: 1, Memory: 32, Threads: 4, KeyLen: 32})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err = h.IDKey(t.Context(), genKatPassword, genKatSalt[:7]); err != InvalidSaltLengthError(7) {
+		t.Errorf("got err %v but should have given %v", err, InvalidSaltLengthError(7))
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	if _, err = h.IDKey(ctx, genKatPassword, genKatSalt); err != context.Canceled {
+		t.Errorf("got err %v but should have given %v", err, context.Canceled)
+	}
+}
+
+func TestHasherConcurrent(t *testing.T) {
+	h, err := NewHasher(Params{Time: 1, Memory: 1024, Threads: 2, KeyLen: 32})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := IDKey([]byte("password"), []byte("somesalt"), 1, 1024, 2, 32)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Go(func() {
+			for j := 0; j < 4; j++ {
+				hash, err := h.IDKey(t.Context(), []byte("password"), []byte("somesalt"))
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if !bytes.Equal(hash, want) {
+					t.Errorf("derived key does not match - got: %x , want: %x", hash, want)
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if peak := h.PeakMemory(); peak < h.MemorySize() || peak > 4*h.MemorySize() {
+		t.Errorf("expected peak memory between %d and %d but got %d", h.MemorySize(), 4*h.MemorySize(), peak)
+	}
+}
+
+func TestHasherReusesMemory(t *testing.T) {
+	h, err := NewHasher(Params{Time: 1, Memory: 4 * 1024, Threads: 1, KeyLen: 32})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The pool is free to drop entries, which the race detector does at random, so only require that the memory is
+	// handed out again at least once rather than on every derivation.
+	B := h.get()
+	h.put(B)
+
+	reused := 0
+
+	for i := 0; i < 16; i++ {
+		next := h.get()
+		if next == B {
+			reused++
+		}
+
+		if h.inUse.Load() != h.MemorySize() {
+			t.Errorf("expected %d bytes in use but got %d", h.MemorySize(), h.inUse.Load())
+		}
+
+		h.put(next)
+		B = next
+	}
+
+	if reused == 0 {
+		t.Error("expected the memory to be reused by later derivations")
+	}
+}
+
+func BenchmarkHasher(b *testing.B) {
+	h, err := NewHasher(Params{Time: 3, Memory: 32 * 1024, Threads: 1, KeyLen: 32})
+	if err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+
+	password := []byte("password")
+	salt := []byte("choosing random salts is hard")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		h.IDKey(context.Background(), password, salt)
+	}
+}
@@ -0,0 +1,90 @@
+package argon2
+
+import "strconv"
+
+// Mode is an Argon2 variant, one of Argon2d, Argon2i, or Argon2id. It allows the variant to be selected by name, for
+// example from configuration.
+type Mode int
+
+// ParseMode returns the Mode with the name s as returned by Mode.String, i.e. argon2d, argon2i, or argon2id. It
+// returns an InvalidModeError if s is not the name of a Mode.
+func ParseMode(s string) (Mode, error) {
+	return parseMode([]byte(s))
+}
+
+func parseMode(name []byte) (Mode, error) {
+	switch string(name) {
+	case "argon2d":
+		return Argon2d, nil
+	case "argon2i":
+		return Argon2i, nil
+	case "argon2id":
+		return Argon2id, nil
+	default:
+		return 0, InvalidModeError(name)
+	}
+}
+
+// String returns the name of the Mode as used in the PHC string format, i.e. argon2d, argon2i, or argon2id.
+func (m Mode) String() string {
+	if !m.valid() {
+		return "Mode(" + strconv.Itoa(int(m)) + ")"
+	}
+
+	return variants[m]
+}
+
+// MarshalText implements encoding.TextMarshaler, returning an InvalidModeError if the Mode is not valid.
+func (m Mode) MarshalText() ([]byte, error) {
+	if !m.valid() {
+		return nil, InvalidModeError(m.String())
+	}
+
+	return []byte(variants[m]), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler in the same manner as ParseMode.
+func (m *Mode) UnmarshalText(text []byte) error {
+	mode, err := parseMode(text)
+	if err != nil {
+		return err
+	}
+
+	*m = mode
+
+	return nil
+}
+
+// KeyFunc returns the function which derives keys using the Mode, i.e. DKey, IKey, or IDKey. It panics if the Mode is
+// not valid.
+func (m Mode) KeyFunc() KeyFunc {
+	switch m {
+	case Argon2d:
+		return DKey
+	case Argon2i:
+		return IKey
+	case Argon2id:
+		return IDKey
+	default:
+		panic("argon2: invalid mode " + m.String())
+	}
+}
+
+// KeyWithSecretFunc returns the function which derives keys using the Mode with a secret and associated data, i.e.
+// DKeyWithSecret, IKeyWithSecret, or IDKeyWithSecret. It panics if the Mode is not valid.
+func (m Mode) KeyWithSecretFunc() KeyWithSecretFunc {
+	switch m {
+	case Argon2d:
+		return DKeyWithSecret
+	case Argon2i:
+		return IKeyWithSecret
+	case Argon2id:
+		return IDKeyWithSecret
+	default:
+		panic("argon2: invalid mode " + m.String())
+	}
+}
+
+func (m Mode) valid() bool {
+	return m >= Argon2d && m <= Argon2id
+}
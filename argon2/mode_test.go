@@ -0,0 +1,123 @@
+package argon2
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMode(t *testing.T) {
+	testCases := []struct {
+		name string
+		mode Mode
+	}{
+		{"argon2d", Argon2d},
+		{"argon2i", Argon2i},
+		{"argon2id", Argon2id},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mode, err := ParseMode(tc.name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if mode != tc.mode {
+				t.Errorf("expected mode %d but got %d", tc.mode, mode)
+			}
+
+			if mode.String() != tc.name {
+				t.Errorf("expected name %s but got %s", tc.name, mode.String())
+			}
+		})
+	}
+
+	for _, name := range []string{"", "argon2", "Argon2id", "argon2x", " argon2id"} {
+		if _, err := ParseMode(name); err != InvalidModeError(name) {
+			t.Errorf("got err %v but should have given %v", err, InvalidModeError(name))
+		}
+	}
+
+	if s := Mode(3).String(); s != "Mode(3)" {
+		t.Errorf("expected name Mode(3) but got %s", s)
+	}
+}
+
+func TestModeText(t *testing.T) {
+	var config struct {
+		Mode Mode `json:"mode"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"mode":"argon2i"}`), &config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if config.Mode != Argon2i {
+		t.Errorf("expected mode %s but got %s", Argon2i, config.Mode)
+	}
+
+	b, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(b) != `{"mode":"argon2i"}` {
+		t.Errorf("expected %s but got %s", `{"mode":"argon2i"}`, b)
+	}
+
+	if err = json.Unmarshal([]byte(`{"mode":"argon2x"}`), &config); err == nil {
+		t.Errorf("expected an error for an invalid mode")
+	}
+
+	if config.Mode != Argon2i {
+		t.Errorf("expected an invalid mode to leave the mode unchanged but got %s", config.Mode)
+	}
+
+	config.Mode = -1
+
+	if _, err = json.Marshal(config); err == nil {
+		t.Errorf("expected an error for an invalid mode")
+	}
+}
+
+func TestModeKeyFunc(t *testing.T) {
+	password, salt := []byte("password"), []byte("somesalt")
+
+	for i, v := range testVectors {
+		want, err := hex.DecodeString(v.hash)
+		if err != nil {
+			t.Fatalf("Test %d: failed to decode hash: %v", i, err)
+		}
+
+		if hash := v.mode.KeyFunc()(password, salt, v.time, v.memory, v.threads, uint32(len(want))); !bytes.Equal(hash, want) {
+			t.Errorf("Test %d - got: %x want: %x", i, hash, want)
+		}
+	}
+
+	for _, mode := range []Mode{Argon2d, Argon2i, Argon2id} {
+		want := mode.KeyFunc()(genKatPassword, genKatSalt, 3, 32, 4, 32)
+		hash := mode.KeyWithSecretFunc()(genKatPassword, genKatSalt, nil, nil, 3, 32, 4, 32)
+
+		if !bytes.Equal(hash, want) {
+			t.Errorf("%s: KeyWithSecretFunc without a secret does not match KeyFunc - got: %x want: %x", mode, hash, want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected KeyFunc to panic for an invalid mode")
+		}
+	}()
+
+	Mode(3).KeyFunc()
+}
+
+func TestDigestInvalidMode(t *testing.T) {
+	d := &Digest{Params: Params{Time: 1, Memory: 64, Threads: 1, KeyLen: 32}, Salt: []byte("somesalt"), Key: make([]byte, 32), Mode: 3}
+
+	if err := d.Verify([]byte("password")); err != InvalidModeError("Mode(3)") {
+		t.Errorf("got err %v but should have given %v", err, InvalidModeError("Mode(3)"))
+	}
+}
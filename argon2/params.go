@@ -0,0 +1,92 @@
+package argon2
+
+import "io"
+
+// Params are the cost parameters and optional inputs of an Argon2 key derivation. The zero value is not valid, at a
+// minimum Time, Memory, Threads, and KeyLen must be set.
+type Params struct {
+	// Time is the number of passes over the memory (t).
+	Time uint32
+
+	// Memory is the memory size in KiB (m). It must be at least MinMemoryPerThread multiplied by Threads.
+	Memory uint32
+
+	// Threads is the degree of parallelism (p).
+	Threads uint32
+
+	// KeyLen is the length of the derived key in bytes (T).
+	KeyLen uint32
+
+	// Secret is the optional secret value (K), for example a pepper which is stored separately from the hashes.
+	Secret []byte
+
+	// Data is the optional associated data (X), for example a tenant identifier the key is bound to.
+	Data []byte
+
+	// Version is the Argon2 version (v), either Version10 or Version13. The zero value selects Version. Version10
+	// should only be used to verify legacy hashes.
+	Version uint32
+
+	// Executor runs the segments of the derivation. If nil each segment runs on its own goroutine. It has no effect on
+	// the derived key.
+	Executor Executor
+
+	// Trace, if not nil, receives the intermediate state of the derivation in the format of the genkat tool of the
+	// reference implementation: the inputs, the pre-hashing digest H0, the memory after each pass, and the tag. The
+	// output can be compared with the known answer test files of the reference implementation. As it includes the
+	// password and Secret it must only be used for debugging. An error writing to Trace is returned by the derivation.
+	Trace io.Writer
+}
+
+// Validate checks the parameters against the bounds in RFC 9106 Section 3.1, returning one of the InvalidTimeError,
+// InvalidThreadsError, InvalidMemoryError, InvalidKeyLengthError, or InvalidVersionError types if they are not met.
+func (p Params) Validate() error {
+	switch p.Version {
+	case 0, Version10, Version13:
+		break
+	default:
+		return InvalidVersionError(p.Version)
+	}
+
+	if p.Time < MinTime {
+		return InvalidTimeError(p.Time)
+	}
+
+	if p.Threads < MinThreads || p.Threads > MaxThreads {
+		return InvalidThreadsError(p.Threads)
+	}
+
+	if p.Memory < MinMemoryPerThread*p.Threads {
+		return InvalidMemoryError{Memory: p.Memory, Threads: p.Threads}
+	}
+
+	if p.KeyLen < MinKeyLength {
+		return InvalidKeyLengthError(p.KeyLen)
+	}
+
+	return nil
+}
+
+func (p Params) version() uint32 {
+	if p.Version == 0 {
+		return Version
+	}
+
+	return p.Version
+}
+
+func (p Params) executor() Executor {
+	if p.Executor == nil {
+		return goExecutor{}
+	}
+
+	return p.Executor
+}
+
+func checkSalt(salt []byte) error {
+	if n := uint64(len(salt)); n < MinSaltLength || n > MaxSaltLength {
+		return InvalidSaltLengthError(n)
+	}
+
+	return nil
+}
@@ -0,0 +1,314 @@
+package argon2
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"strconv"
+)
+
+// Digest is an Argon2 hash in the reference PHC string format produced by libargon2:
+//
+//	$argon2<T>[$v=<num>]$m=<num>,t=<num>,p=<num>[,keyid=<bin>][,data=<bin>]$<salt>$<hash>
+//
+// Where <bin> is the standard base64 encoding without padding. The Secret of the embedded Params is never encoded,
+// and the KeyLen is always the length of the Key.
+type Digest struct {
+	Params
+
+	// KeyID is the optional identifier of the secret used to derive the key.
+	KeyID []byte
+
+	// Salt is the salt used to derive the key.
+	Salt []byte
+
+	// Key is the derived key.
+	Key []byte
+
+	// Mode is the Argon2 variant used to derive the key.
+	Mode Mode
+}
+
+// IDHash derives a key from the password, salt, and params using Argon2id in the same manner as DeriveIDKey and
+// returns it in the PHC string format. Use Verify, as defined in this package, to compare the returned hash with its
+// cleartext version. Remember to get a good random salt.
+func IDHash(password, salt []byte, params Params) ([]byte, error) {
+	return hashEncoded(Argon2id, password, salt, params)
+}
+
+// IHash derives a key from the password, salt, and params using Argon2i in the same manner as DeriveIKey and
+// returns it in the PHC string format.
+func IHash(password, salt []byte, params Params) ([]byte, error) {
+	return hashEncoded(Argon2i, password, salt, params)
+}
+
+// DHash derives a key from the password, salt, and params using Argon2d in the same manner as DeriveDKey and
+// returns it in the PHC string format.
+func DHash(password, salt []byte, params Params) ([]byte, error) {
+	return hashEncoded(Argon2d, password, salt, params)
+}
+
+// Verify compares an Argon2 hash in the PHC string format with its possible plaintext equivalent. Returns nil on
+// success, or an error on failure.
+func Verify(encoded, password []byte) error {
+	return VerifyWithSecret(encoded, password, nil)
+}
+
+// VerifyWithSecret is like Verify but uses the provided secret value (K) to derive the key.
+func VerifyWithSecret(encoded, password, secret []byte) error {
+	d, err := Decode(encoded)
+	if err != nil {
+		return err
+	}
+
+	d.Secret = secret
+
+	return d.Verify(password)
+}
+
+// Decode parses an Argon2 hash in the PHC string format. If the version is absent it is assumed to be Version10, in
+// the same manner as libargon2. The decoded Params are validated and the salt length is checked.
+func Decode(encoded []byte) (d *Digest, err error) {
+	d = &Digest{}
+
+	if len(encoded) == 0 || encoded[0] != '$' {
+		return nil, ErrInvalidHash
+	}
+
+	encoded = encoded[1:]
+
+	var variant []byte
+
+	if variant, encoded = cut(encoded, '$'); encoded == nil {
+		return nil, ErrInvalidHash
+	}
+
+	if d.Mode, err = parseMode(variant); err != nil {
+		return nil, ErrInvalidHash
+	}
+
+	var field []byte
+
+	field, encoded = cut(encoded, '$')
+
+	d.Version = Version10
+
+	if bytes.HasPrefix(field, []byte("v=")) {
+		if d.Version, err = decodeDecimal(field[2:]); err != nil {
+			return nil, err
+		}
+
+		// Unlike Params.Validate the zero version does not mean the default.
+		if d.Version != Version10 && d.Version != Version13 {
+			return nil, InvalidVersionError(d.Version)
+		}
+
+		field, encoded = cut(encoded, '$')
+	}
+
+	if encoded == nil {
+		return nil, ErrInvalidHash
+	}
+
+	if err = d.decodeParams(field); err != nil {
+		return nil, err
+	}
+
+	if field, encoded = cut(encoded, '$'); encoded == nil {
+		return nil, ErrInvalidHash
+	}
+
+	if d.Salt, err = decodeBase64(field); err != nil {
+		return nil, err
+	}
+
+	if d.Key, err = decodeBase64(encoded); err != nil {
+		return nil, err
+	}
+
+	d.KeyLen = uint32(len(d.Key))
+
+	if err = d.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err = checkSalt(d.Salt); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Verify derives a key from the password using the Params and Salt and compares it with the Key in constant time.
+// Returns nil on success, or an error on failure.
+func (d *Digest) Verify(password []byte) error {
+	return d.VerifyContext(context.Background(), password)
+}
+
+// VerifyContext is like Verify but stops the derivation in the same manner as DeriveIDKeyContext.
+func (d *Digest) VerifyContext(ctx context.Context, password []byte) error {
+	params := d.Params
+	params.KeyLen = uint32(len(d.Key))
+
+	key, err := deriveKeyChecked(ctx, d.Mode, password, d.Salt, params)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(key, d.Key) == 1 {
+		return nil
+	}
+
+	return ErrMismatchedHashAndPassword
+}
+
+// Encode returns the Digest in the PHC string format. The version is always included.
+func (d *Digest) Encode() []byte {
+	b := make([]byte, 0, 64+base64.RawStdEncoding.EncodedLen(len(d.KeyID))+base64.RawStdEncoding.EncodedLen(len(d.Data))+
+		base64.RawStdEncoding.EncodedLen(len(d.Salt))+base64.RawStdEncoding.EncodedLen(len(d.Key)))
+
+	b = append(b, '$')
+	b = append(b, d.Mode.String()...)
+	b = append(b, "$v="...)
+	b = strconv.AppendUint(b, uint64(d.version()), 10)
+	b = append(b, "$m="...)
+	b = strconv.AppendUint(b, uint64(d.Memory), 10)
+	b = append(b, ",t="...)
+	b = strconv.AppendUint(b, uint64(d.Time), 10)
+	b = append(b, ",p="...)
+	b = strconv.AppendUint(b, uint64(d.Threads), 10)
+
+	if len(d.KeyID) != 0 {
+		b = append(b, ",keyid="...)
+		b = base64.RawStdEncoding.AppendEncode(b, d.KeyID)
+	}
+
+	if len(d.Data) != 0 {
+		b = append(b, ",data="...)
+		b = base64.RawStdEncoding.AppendEncode(b, d.Data)
+	}
+
+	b = append(b, '$')
+	b = base64.RawStdEncoding.AppendEncode(b, d.Salt)
+	b = append(b, '$')
+	b = base64.RawStdEncoding.AppendEncode(b, d.Key)
+
+	return b
+}
+
+// String returns the Digest in the PHC string format.
+func (d *Digest) String() string {
+	return string(d.Encode())
+}
+
+// Variant returns the name of the Argon2 variant, i.e. argon2d, argon2i, or argon2id.
+func (d *Digest) Variant() string {
+	return d.Mode.String()
+}
+
+// decodeParams decodes the m, t, and p parameters which must appear in that order followed by the optional keyid and
+// data parameters.
+func (d *Digest) decodeParams(field []byte) (err error) {
+	var param []byte
+
+	for i, name := range []string{"m=", "t=", "p="} {
+		param, field = cut(field, ',')
+		if !bytes.HasPrefix(param, []byte(name)) || (i < 2 && field == nil) {
+			return ErrInvalidHash
+		}
+
+		var value uint32
+
+		if value, err = decodeDecimal(param[2:]); err != nil {
+			return err
+		}
+
+		switch i {
+		case 0:
+			d.Memory = value
+		case 1:
+			d.Time = value
+		case 2:
+			d.Threads = value
+		}
+	}
+
+	if field != nil {
+		param, field = cut(field, ',')
+
+		if bytes.HasPrefix(param, []byte("keyid=")) {
+			if d.KeyID, err = decodeBase64(param[6:]); err != nil {
+				return err
+			}
+
+			if field == nil {
+				return nil
+			}
+
+			param, field = cut(field, ',')
+		}
+
+		if !bytes.HasPrefix(param, []byte("data=")) || field != nil {
+			return ErrInvalidHash
+		}
+
+		if d.Data, err = decodeBase64(param[5:]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hashEncoded(mode Mode, password, salt []byte, params Params) ([]byte, error) {
+	key, err := deriveKeyChecked(context.Background(), mode, password, salt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Digest{Params: params, Salt: salt, Key: key, Mode: mode}
+
+	return d.Encode(), nil
+}
+
+// cut slices b around the first instance of sep, returning the text before and after sep. If sep does not appear
+// in b, cut returns b and nil.
+func cut(b []byte, sep byte) (before, after []byte) {
+	if i := bytes.IndexByte(b, sep); i >= 0 {
+		return b[:i], b[i+1:]
+	}
+
+	return b, nil
+}
+
+// decodeDecimal decodes an unsigned 32-bit decimal number without a sign or leading zeros.
+func decodeDecimal(b []byte) (uint32, error) {
+	if len(b) == 0 || (b[0] == '0' && len(b) != 1) || b[0] == '+' || b[0] == '-' {
+		return 0, ErrInvalidHash
+	}
+
+	value, err := strconv.ParseUint(string(b), 10, 32)
+	if err != nil {
+		return 0, ErrInvalidHash
+	}
+
+	return uint32(value), nil
+}
+
+// decodeBase64 decodes the standard base64 encoding without padding, rejecting non-canonical encodings and the
+// newline characters which are otherwise ignored by the decoder.
+func decodeBase64(b []byte) ([]byte, error) {
+	if bytes.ContainsAny(b, "\r\n") {
+		return nil, ErrInvalidHash
+	}
+
+	value := make([]byte, base64.RawStdEncoding.DecodedLen(len(b)))
+
+	n, err := base64.RawStdEncoding.Strict().Decode(value, b)
+	if err != nil {
+		return nil, ErrInvalidHash
+	}
+
+	return value[:n], nil
+}
@@ -0,0 +1,143 @@
+package argon2
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Taken from https://github.com/P-H-C/phc-winner-argon2/blob/master/src/test.c
+var phcTests = []struct {
+	password, encoded string
+}{
+	{"password", "$argon2i$m=65536,t=2,p=1$c29tZXNhbHQ$9sTbSlTio3Biev89thdrlKKiCaYsjjYVJxGAL3swxpQ"},
+	{"password", "$argon2i$m=256,t=2,p=2$c29tZXNhbHQ$tsEVYKap1h6scGt5ovl9aLRGOqOth+AMB+KwHpDFZPs"},
+	{"password", "$argon2i$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$wWKIMhR9lyDFvRz9YTZweHKfbftvj+qf+YFY4NeBbtA"},
+	{"password", "$argon2i$v=19$m=256,t=2,p=1$c29tZXNhbHQ$iekCn0Y3spW+sCcFanM2xBT63UP2sghkUoHLIUpWRS8"},
+	{"password", "$argon2i$v=19$m=256,t=2,p=2$c29tZXNhbHQ$T/XOJ2mh1/TIpJHfCdQan76Q5esCFVoT5MAeIM1Oq2E"},
+	{"password", "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc"},
+	{"password", "$argon2id$v=19$m=256,t=2,p=2$c29tZXNhbHQ$bQk8UB/VmZZF4Oo79iDXuL5/0ttZwg2f/5U52iv1cDc"},
+}
+
+func TestVerify(t *testing.T) {
+	for i, v := range phcTests {
+		if err := Verify([]byte(v.encoded), []byte(v.password)); err != nil {
+			t.Errorf("Test %d: failed to verify %s: %v", i, v.encoded, err)
+		}
+
+		if err := Verify([]byte(v.encoded), []byte("notthepassword")); err != ErrMismatchedHashAndPassword {
+			t.Errorf("Test %d: %s and notthepassword should be mismatched but got %v", i, v.encoded, err)
+		}
+	}
+}
+
+func TestHashRoundTrip(t *testing.T) {
+	params := Params{Time: 3, Memory: 32, Threads: 4, KeyLen: 32, Secret: genKatSecret, Data: genKatAAD}
+
+	testCases := []struct {
+		name    string
+		hash    func(password, salt []byte, params Params) ([]byte, error)
+		variant string
+	}{
+		{"Argon2d", DHash, "argon2d"},
+		{"Argon2i", IHash, "argon2i"},
+		{"Argon2id", IDHash, "argon2id"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded, err := tc.hash(genKatPassword, genKatSalt, params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want := "$" + tc.variant + "$v=19$m=32,t=3,p=4,data=BAQEBAQEBAQEBAQE$AgICAgICAgICAgICAgICAg$"
+			if !bytes.HasPrefix(encoded, []byte(want)) {
+				t.Errorf("encoded hash %s should have the prefix %s", encoded, want)
+			}
+
+			d, err := Decode(encoded)
+			if err != nil {
+				t.Fatalf("failed to decode %s: %v", encoded, err)
+			}
+
+			if d.Variant() != tc.variant {
+				t.Errorf("expected variant %s but got %s", tc.variant, d.Variant())
+			}
+
+			if !bytes.Equal(encoded, d.Encode()) {
+				t.Errorf("re-encoded hash %s should equal %s", d.Encode(), encoded)
+			}
+
+			if err = Verify(encoded, genKatPassword); err != ErrMismatchedHashAndPassword {
+				t.Errorf("verify without the secret should be mismatched but got %v", err)
+			}
+
+			if err = VerifyWithSecret(encoded, genKatPassword, genKatSecret); err != nil {
+				t.Errorf("failed to verify with the secret: %v", err)
+			}
+		})
+	}
+}
+
+func TestDecodeKeyID(t *testing.T) {
+	encoded := []byte("$argon2id$v=19$m=256,t=2,p=2,keyid=AQID,data=BAUG$c29tZXNhbHQ$bQk8UB/VmZZF4Oo79iDXuL5/0ttZwg2f/5U52iv1cDc")
+
+	d, err := Decode(encoded)
+	if err != nil {
+		t.Fatalf("failed to decode %s: %v", encoded, err)
+	}
+
+	if !bytes.Equal(d.KeyID, []byte{1, 2, 3}) {
+		t.Errorf("expected keyid 010203 but got %x", d.KeyID)
+	}
+
+	if !bytes.Equal(d.Data, []byte{4, 5, 6}) {
+		t.Errorf("expected data 040506 but got %x", d.Data)
+	}
+
+	if !bytes.Equal(encoded, d.Encode()) {
+		t.Errorf("re-encoded hash %s should equal %s", d.Encode(), encoded)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		encoded string
+		err     error
+	}{
+		{"ShouldErrEmpty", "", ErrInvalidHash},
+		{"ShouldErrMissingDollarBeforeSalt", "$argon2i$m=65536,t=2,p=1c29tZXNhbHQ$9sTbSlTio3Biev89thdrlKKiCaYsjjYVJxGAL3swxpQ", ErrInvalidHash},
+		{"ShouldErrMissingDollarBeforeHash", "$argon2i$m=65536,t=2,p=1$c29tZXNhbHQ9sTbSlTio3Biev89thdrlKKiCaYsjjYVJxGAL3swxpQ", ErrInvalidHash},
+		{"ShouldErrEmptySalt", "$argon2i$m=65536,t=2,p=1$$9sTbSlTio3Biev89thdrlKKiCaYsjjYVJxGAL3swxpQ", InvalidSaltLengthError(0)},
+		{"ShouldErrUnknownVariant", "$argon2x$v=19$m=256,t=2,p=1$c29tZXNhbHQ$iekCn0Y3spW+sCcFanM2xBT63UP2sghkUoHLIUpWRS8", ErrInvalidHash},
+		{"ShouldErrUnknownVersion", "$argon2i$v=18$m=256,t=2,p=1$c29tZXNhbHQ$iekCn0Y3spW+sCcFanM2xBT63UP2sghkUoHLIUpWRS8", InvalidVersionError(18)},
+		{"ShouldErrZeroVersion", "$argon2id$v=0$m=256,t=2,p=1$c29tZXNhbHQ$iekCn0Y3spW+sCcFanM2xBT63UP2sghkUoHLIUpWRS8", InvalidVersionError(0)},
+		{"ShouldErrVersion20", "$argon2id$v=20$m=256,t=2,p=1$c29tZXNhbHQ$iekCn0Y3spW+sCcFanM2xBT63UP2sghkUoHLIUpWRS8", InvalidVersionError(20)},
+		{"ShouldErrParamsOutOfOrder", "$argon2i$v=19$t=2,m=256,p=1$c29tZXNhbHQ$iekCn0Y3spW+sCcFanM2xBT63UP2sghkUoHLIUpWRS8", ErrInvalidHash},
+		{"ShouldErrLeadingZero", "$argon2i$v=19$m=0256,t=2,p=1$c29tZXNhbHQ$iekCn0Y3spW+sCcFanM2xBT63UP2sghkUoHLIUpWRS8", ErrInvalidHash},
+		{"ShouldErrMemoryOverflow", "$argon2i$v=19$m=4294967296,t=2,p=1$c29tZXNhbHQ$iekCn0Y3spW+sCcFanM2xBT63UP2sghkUoHLIUpWRS8", ErrInvalidHash},
+		{"ShouldErrTimeZero", "$argon2i$v=19$m=256,t=0,p=1$c29tZXNhbHQ$iekCn0Y3spW+sCcFanM2xBT63UP2sghkUoHLIUpWRS8", InvalidTimeError(0)},
+		{"ShouldErrUnknownParam", "$argon2i$v=19$m=256,t=2,p=1,x=1$c29tZXNhbHQ$iekCn0Y3spW+sCcFanM2xBT63UP2sghkUoHLIUpWRS8", ErrInvalidHash},
+		{"ShouldErrPaddedSalt", "$argon2i$v=19$m=256,t=2,p=1$c29tZXNhbHQ=$iekCn0Y3spW+sCcFanM2xBT63UP2sghkUoHLIUpWRS8", ErrInvalidHash},
+		{"ShouldErrNonCanonicalSalt", "$argon2i$v=19$m=256,t=2,p=1$c29tZXNhbHR$iekCn0Y3spW+sCcFanM2xBT63UP2sghkUoHLIUpWRS8", ErrInvalidHash},
+		{"ShouldErrNewlineInHash", "$argon2i$v=19$m=256,t=2,p=1$c29tZXNhbHQ$iekCn0Y3spW+sCcFanM2xBT63UP2\nsghkUoHLIUpWRS8", ErrInvalidHash},
+		{"ShouldErrHashTooShort", "$argon2i$v=19$m=256,t=2,p=1$c29tZXNhbHQ$iekC", InvalidKeyLengthError(3)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := Decode([]byte(tc.encoded))
+			if err != tc.err {
+				t.Errorf("got err %v but should have given %v", err, tc.err)
+			}
+			if d != nil {
+				t.Errorf("expected nil digest but got %s", d)
+			}
+
+			if err = Verify([]byte(tc.encoded), []byte("password")); err != tc.err {
+				t.Errorf("Verify gave err %v but should have given %v", err, tc.err)
+			}
+		})
+	}
+}
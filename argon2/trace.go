@@ -0,0 +1,64 @@
+package argon2
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// tracer writes the intermediate state of a derivation in the format of the genkat tool of the reference
+// implementation.
+type tracer struct {
+	w *bufio.Writer
+}
+
+func newTracer(w io.Writer) *tracer {
+	return &tracer{w: bufio.NewWriter(w)}
+}
+
+func (t *tracer) init(mode Mode, password, salt []byte, params Params, version uint32, h0 []byte) {
+	fmt.Fprintf(t.w, "=======================================\n")
+	fmt.Fprintf(t.w, "Argon2%s version number %d\n", variants[mode][len("argon2"):], version)
+	fmt.Fprintf(t.w, "=======================================\n")
+	fmt.Fprintf(t.w, "Memory: %d KiB, Iterations: %d, Parallelism: %d lanes, Tag length: %d bytes\n", params.Memory, params.Time, params.Threads, params.KeyLen)
+
+	t.bytes(fmt.Sprintf("Password[%d]: ", len(password)), password)
+	t.bytes(fmt.Sprintf("Salt[%d]: ", len(salt)), salt)
+	t.bytes(fmt.Sprintf("Secret[%d]: ", len(params.Secret)), params.Secret)
+	t.bytes(fmt.Sprintf("Associated data[%d]: ", len(params.Data)), params.Data)
+	t.bytes("Pre-hashing digest: ", h0)
+}
+
+// pass writes the memory after pass n. Like the reference implementation it writes every word of each block if there
+// are no more than blockLength blocks, otherwise only the first word of each block.
+func (t *tracer) pass(n uint32, B []block) {
+	fmt.Fprintf(t.w, "\n After pass %d:\n", n)
+
+	words := blockLength
+	if len(B) > blockLength {
+		words = 1
+	}
+
+	for i := range B {
+		for j := 0; j < words; j++ {
+			fmt.Fprintf(t.w, "Block %04d [%3d]: %016x\n", i, j, B[i][j])
+		}
+	}
+}
+
+// tag writes the derived key and flushes the output, returning the first error which occurred writing it.
+func (t *tracer) tag(key []byte) error {
+	t.bytes("Tag: ", key)
+
+	return t.w.Flush()
+}
+
+func (t *tracer) bytes(label string, b []byte) {
+	t.w.WriteString(label)
+
+	for _, v := range b {
+		fmt.Fprintf(t.w, "%02x ", v)
+	}
+
+	t.w.WriteByte('\n')
+}
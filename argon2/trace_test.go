@@ -0,0 +1,75 @@
+package argon2
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	// The SHA-256 digests of the known answer test files in the kats directory of the reference implementation, which
+	// are the output of its genkat tool.
+	testCases := []struct {
+		name    string
+		mode    Mode
+		version uint32
+		sum     string
+	}{
+		{"argon2d", Argon2d, Version13, "73619cfe0f35e52fdd1ca2595ffaa359879467407f98b61f4969c2861cc329ce"},
+		{"argon2d_v16", Argon2d, Version10, "4ec4569a016c3accc6a25a34252b03a6135939b3c452389917a3f3b65878165b"},
+		{"argon2i", Argon2i, Version13, "40a3aeafb092d10cf457a8ee0139c114c911ecf97bd5accf5a99c7ddd6917061"},
+		{"argon2i_v16", Argon2i, Version10, "334f03e627afb67b946a530b90d2e11fb2e6abb44df992c0fb3198c7bacf5930"},
+		{"argon2id", Argon2id, Version13, "ba05643e504fc5778dda99e2d9f42ebe7d22ebb3923cc719fd591b1b14a8d28d"},
+		{"argon2id_v16", Argon2id, Version10, "680774be1d3ad2e74bbc56ee715dd6eb97a58279bf22edc57d00e840ca1ae469"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			params := Params{Time: 3, Memory: 32, Threads: 4, KeyLen: 32, Secret: genKatSecret, Data: genKatAAD, Version: tc.version, Trace: &buf}
+
+			if _, err := derive(t.Context(), tc.mode, genKatPassword, genKatSalt, params); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if sum := sha256.Sum256(buf.Bytes()); hex.EncodeToString(sum[:]) != tc.sum {
+				t.Errorf("trace does not match the known answer test file - got: %x , want: %s", sum, tc.sum)
+			}
+		})
+	}
+}
+
+var errWrite = errors.New("write failed")
+
+type errWriter struct{}
+
+func (errWriter) Write(p []byte) (int, error) {
+	return 0, errWrite
+}
+
+func TestTraceErrors(t *testing.T) {
+	if _, err := DeriveIDKey(genKatPassword, genKatSalt, Params{Time: 1, Memory: 32, Threads: 4, KeyLen: 32, Trace: errWriter{}}); err != errWrite {
+		t.Errorf("got err %v but should have given %v", err, errWrite)
+	}
+}
+
+func TestTraceLargeMemory(t *testing.T) {
+	var buf bytes.Buffer
+
+	if _, err := DeriveIDKey(genKatPassword, genKatSalt, Params{Time: 2, Memory: 256, Threads: 1, KeyLen: 32, Trace: &buf}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// With more than 128 blocks only the first word of each block is written.
+	if n := strings.Count(buf.String(), "Block "); n != 2*256 {
+		t.Errorf("expected %d blocks in the trace but got %d", 2*256, n)
+	}
+
+	if strings.Contains(buf.String(), "[  1]") {
+		t.Errorf("expected only the first word of each block in the trace")
+	}
+}
@@ -5,9 +5,14 @@
 package bcrypt
 
 import (
+	stdbase64 "encoding/base64"
+
 	"github.com/go-crypt/x/base64"
 )
 
+// rawEncoding is the base64 encoding for bcrypt without padding, which decodes into a buffer without allocating.
+var rawEncoding = base64.BcryptEncoding.WithPadding(stdbase64.NoPadding)
+
 // Base64Encode is the base64 encoder for bcrypt.
 func Base64Encode(src []byte) []byte {
 	n := base64.BcryptEncoding.EncodedLen(len(src))
@@ -0,0 +1,78 @@
+package bcrypt
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// BatchItem is a bcrypt hashed password and its possible plaintext equivalent to be compared by a Batch.
+type BatchItem struct {
+	Hash     []byte
+	Password []byte
+}
+
+// Batch compares many bcrypt hashed passwords with their possible plaintext equivalents concurrently, such as when
+// auditing or rehashing the stored hashes of a database. Each goroutine reuses its Blowfish state for all of the
+// comparisons it makes. The zero value of Batch uses one goroutine per CPU and reports no progress.
+type Batch struct {
+	// Workers is the maximum number of goroutines comparing hashes at once. If it is less than 1
+	// runtime.GOMAXPROCS(0) is used instead.
+	Workers int
+
+	// Progress, if not nil, is called after each item is compared with the index of the item, the result of the
+	// comparison, and the number of items compared so far. It is never called concurrently, so it does not need to be
+	// safe for concurrent use, however it delays the other goroutines while it runs.
+	Progress func(index int, err error, completed int)
+}
+
+// Compare compares every item in the same manner as CompareHashAndPasswordContext and returns the results in the order
+// of the items, where a nil error means the password matches the hash. Once ctx is done the items which have not been
+// compared yet are skipped and their result is ctx.Err().
+func (b Batch) Compare(ctx context.Context, items []BatchItem) []error {
+	results := make([]error, len(items))
+
+	workers := b.Workers
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		next      atomic.Int64
+		completed int
+	)
+
+	for range min(workers, len(items)) {
+		wg.Go(func() {
+			s := new(state)
+
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(items) {
+					return
+				}
+
+				err := ctx.Err()
+				if err == nil {
+					err = s.compare(ctx, items[i].Hash, items[i].Password, false)
+				}
+
+				results[i] = err
+
+				if b.Progress != nil {
+					mu.Lock()
+					completed++
+					b.Progress(i, err, completed)
+					mu.Unlock()
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+
+	return results
+}
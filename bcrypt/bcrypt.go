@@ -8,11 +8,13 @@ package bcrypt
 
 // The code is a port of Provos and Mazières's C implementation.
 import (
+	"bytes"
+	"context"
 	"crypto/rand"
 	"crypto/subtle"
+	"encoding/binary"
 	"fmt"
 	"io"
-	"strconv"
 
 	"github.com/go-crypt/x/blowfish"
 )
@@ -23,6 +25,8 @@ type hashed struct {
 	cost  int // allowed range is MinCost to MaxCost
 	major byte
 	minor byte
+
+	prehash bool
 }
 
 // GenerateFromPassword returns the bcrypt hash of the password at the given
@@ -30,11 +34,25 @@ type hashed struct {
 // DefaultCost, instead. Use CompareHashAndPassword, as defined in this package,
 // to compare the returned hashed password with its cleartext version.
 func GenerateFromPassword(password []byte, cost int) ([]byte, error) {
-	p, err := newFromPassword(password, cost)
-	if err != nil {
+	return GenerateFromPasswordMinor(password, cost, minorVersion)
+}
+
+// GenerateFromPasswordMinor is like GenerateFromPassword but returns a hash
+// with the prefix of the given minor version, i.e. MinorVersionA,
+// MinorVersionB, MinorVersionX, MinorVersionY, or MinorVersionNone for a $2$
+// hash. The password is expanded into the key as it is by the system the minor
+// version originates from, see the documentation of the constants.
+func GenerateFromPasswordMinor(password []byte, cost int, minor byte) ([]byte, error) {
+	var (
+		salt []byte
+		err  error
+	)
+
+	if salt, err = NewSalt(); err != nil {
 		return nil, err
 	}
-	return p.Hash(), nil
+
+	return GenerateFromPasswordSaltMinor(password, salt, cost, minor)
 }
 
 // GenerateFromPasswordSalt returns the bcrypt hash of the password at the given
@@ -42,7 +60,30 @@ func GenerateFromPassword(password []byte, cost int) ([]byte, error) {
 // DefaultCost, instead. Use CompareHashAndPassword, as defined in this package,
 // to compare the returned hashed password with its cleartext version.
 func GenerateFromPasswordSalt(password, salt []byte, cost int) ([]byte, error) {
-	p, err := newFromPasswordSalt(password, salt, cost)
+	return GenerateFromPasswordSaltMinor(password, salt, cost, minorVersion)
+}
+
+// GenerateFromPasswordSaltMinor is like GenerateFromPasswordSalt but returns a
+// hash with the prefix of the given minor version in the same manner as
+// GenerateFromPasswordMinor.
+func GenerateFromPasswordSaltMinor(password, salt []byte, cost int, minor byte) ([]byte, error) {
+	p, err := newFromPasswordSalt(context.Background(), password, salt, cost, minor, false)
+	if err != nil {
+		return nil, err
+	}
+	return p.Hash(), nil
+}
+
+// GenerateFromPasswordContext is like GenerateFromPassword but periodically
+// checks ctx during the key expansion, and returns ctx.Err() if it is done
+// before the hash is complete.
+func GenerateFromPasswordContext(ctx context.Context, password []byte, cost int) ([]byte, error) {
+	salt, err := NewSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := newFromPasswordSalt(ctx, password, salt, cost, minorVersion, false)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +93,7 @@ func GenerateFromPasswordSalt(password, salt []byte, cost int) ([]byte, error) {
 // Key returns a new key from password/salt combination. Salt must be 16 bytes. For storage the salt needs to be encoded
 // with bcrypt.Base64Encode.
 func Key(password, salt []byte, cost int) ([]byte, error) {
-	p, err := newFromPasswordSalt(password, salt, cost)
+	p, err := newFromPasswordSalt(context.Background(), password, salt, cost, minorVersion, false)
 	if err != nil {
 		return nil, err
 	}
@@ -62,24 +103,77 @@ func Key(password, salt []byte, cost int) ([]byte, error) {
 // CompareHashAndPassword compares a bcrypt hashed password with its possible
 // plaintext equivalent. Returns nil on success, or an error on failure.
 func CompareHashAndPassword(hashedPassword, password []byte) error {
+	return compareHashAndPassword(context.Background(), hashedPassword, password, false)
+}
+
+// CompareHashAndPasswordContext is like CompareHashAndPassword but periodically checks ctx during the key expansion,
+// and returns ctx.Err() if it is done before the comparison is complete.
+func CompareHashAndPasswordContext(ctx context.Context, hashedPassword, password []byte) error {
+	return compareHashAndPassword(ctx, hashedPassword, password, false)
+}
+
+func compareHashAndPassword(ctx context.Context, hashedPassword, password []byte, strict bool) error {
+	return new(state).compare(ctx, hashedPassword, password, strict)
+}
+
+func (s *state) compare(ctx context.Context, hashedPassword, password []byte, strict bool) error {
 	p, err := newFromHash(hashedPassword)
 	if err != nil {
 		return err
 	}
 
-	otherHash, err := bcrypt(password, p.cost, p.salt)
-	if err != nil {
+	if p.prehash {
+		password = preHashPassword(password, p.salt)
+	} else if strict && len(password) > MaxPasswordLength {
+		return ErrPasswordTooLong
+	}
+
+	key := expandPassword(password, p.minor)
+
+	initial := safetyKey(key, p.minor)
+	if initial == nil {
+		initial = key
+	}
+
+	if ok, err := s.match(ctx, p, key, initial); ok || err != nil {
 		return err
 	}
 
-	otherP := &hashed{otherHash, p.salt, p.cost, p.major, p.minor}
-	if subtle.ConstantTimeCompare(p.Hash(), otherP.Hash()) == 1 {
-		return nil
+	if p.minor == MinorVersionA {
+		// A $2a$ hash of a password affected by the countermeasure of
+		// crypt_blowfish may have been generated by an implementation which
+		// does not apply it, such as OpenBSD.
+		if &initial[0] != &key[0] {
+			if ok, err := s.match(ctx, p, key, key); ok || err != nil {
+				return err
+			}
+		}
+
+		// A $2a$ hash of a long password may have been generated by OpenBSD, or
+		// by an implementation which expands the password as for $2b$.
+		if key = wraparoundKey(key); key != nil {
+			if ok, err := s.match(ctx, p, key, key); ok || err != nil {
+				return err
+			}
+		}
 	}
 
 	return ErrMismatchedHashAndPassword
 }
 
+// match reports whether the hash of key, using initial for the initial
+// expansion of the key schedule, is the hash of p.
+func (s *state) match(ctx context.Context, p *hashed, key, initial []byte) (bool, error) {
+	hash, err := s.key(ctx, key, initial, p.cost, p.salt)
+	if err != nil {
+		return false, err
+	}
+
+	otherP := &hashed{hash, p.salt, p.cost, p.major, p.minor, p.prehash}
+
+	return subtle.ConstantTimeCompare(p.Hash(), otherP.Hash()) == 1, nil
+}
+
 // Cost returns the hashing cost used to create the given hashed
 // password. When, in the future, the hashing cost of a password system needs
 // to be increased in order to adjust for greater computational power, this
@@ -101,25 +195,36 @@ func NewSalt() (salt []byte, err error) {
 	return salt, err
 }
 
-func newFromPasswordSalt(password, salt []byte, cost int) (p *hashed, err error) {
+func newFromPasswordSalt(ctx context.Context, password, salt []byte, cost int, minor byte, prehash bool) (p *hashed, err error) {
 	if cost < MinCost {
 		cost = DefaultCost
 	}
 	p = new(hashed)
 	p.major = majorVersion
-	p.minor = minorVersion
+
+	if minor != MinorVersionNone {
+		if err = checkMinor(minor); err != nil {
+			return nil, err
+		}
+	}
+	p.minor = minor
 
 	if err = checkSalt(salt); err != nil {
 		return nil, err
 	}
 	p.salt = Base64Encode(salt)
 
+	if prehash {
+		password = preHashPassword(password, p.salt)
+		p.prehash = true
+	}
+
 	if err = checkCost(cost); err != nil {
 		return nil, err
 	}
 	p.cost = cost
 
-	hash, err := bcrypt(password, p.cost, p.salt)
+	hash, err := bcrypt(ctx, password, p.minor, p.cost, p.salt)
 	if err != nil {
 		return nil, err
 	}
@@ -134,41 +239,65 @@ func newFromPassword(password []byte, cost int) (p *hashed, err error) {
 		return nil, err
 	}
 
-	return newFromPasswordSalt(password, salt, cost)
+	return newFromPasswordSalt(context.Background(), password, salt, cost, minorVersion, false)
 }
 
 func newFromHashPartial(hashedSecret []byte) (p *hashed, secret []byte, err error) {
-	if len(hashedSecret) < minHashSize {
-		return nil, nil, ErrHashTooShort
-	}
+	p, _, secret, err = decodeHashPartial(hashedSecret)
+
+	return p, secret, err
+}
 
+// decodeHashPartial decodes the prefix, version, and cost of the hash, returning the remaining secret and its offset
+// in the hash.
+func decodeHashPartial(hashedSecret []byte) (p *hashed, offset int, secret []byte, err error) {
 	p = new(hashed)
 
-	n, err := p.decodeVersion(hashedSecret)
-	if err != nil {
-		return nil, nil, err
+	if bytes.HasPrefix(hashedSecret, []byte(preHashPrefix)) {
+		hashedSecret = hashedSecret[len(preHashPrefix):]
+		offset = len(preHashPrefix)
+		p.prehash = true
 	}
 
-	hashedSecret = hashedSecret[n:]
+	if len(hashedSecret) < minHashSize {
+		return nil, 0, nil, &ParseError{Field: FieldHash, Offset: offset + len(hashedSecret), Err: ErrHashTooShort}
+	}
 
-	n, err = p.decodeCost(hashedSecret)
+	n, err := p.decodeVersion(hashedSecret, offset)
 	if err != nil {
-		return nil, nil, err
+		return nil, 0, nil, err
 	}
 
-	hashedSecret = hashedSecret[n:]
+	hashedSecret, offset = hashedSecret[n:], offset+n
+
+	n, err = p.decodeCost(hashedSecret, offset)
+	if err != nil {
+		return nil, 0, nil, err
+	}
 
-	return p, hashedSecret, nil
+	return p, offset + n, hashedSecret[n:], nil
 }
 
 func newFromHash(hashedSecret []byte) (*hashed, error) {
-	p, hashedSecret, err := newFromHashPartial(hashedSecret)
+	p, offset, hashedSecret, err := decodeHashPartial(hashedSecret)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(hashedSecret) != EncodedSaltSize+EncodedHashSize {
-		return nil, ErrSecretInvalidLength
+	if n := len(hashedSecret); n != EncodedSaltSize+EncodedHashSize {
+		return nil, &ParseError{Field: FieldChecksum, Offset: offset + min(n, EncodedSaltSize+EncodedHashSize), Err: ErrSecretInvalidLength}
+	}
+
+	for i, c := range hashedSecret {
+		if isBase64(c) {
+			continue
+		}
+
+		if i < EncodedSaltSize {
+			return nil, &ParseError{Field: FieldSalt, Offset: offset + i, Err: ErrInvalidCharacter}
+		}
+
+		return nil, &ParseError{Field: FieldChecksum, Offset: offset + i, Err: ErrInvalidCharacter}
 	}
 
 	p.salt, p.hash = DecodeSecret(hashedSecret)
@@ -191,11 +320,36 @@ func DecodeSecret(secret []byte) (salt, key []byte) {
 	return
 }
 
-func bcrypt(password []byte, cost int, salt []byte) ([]byte, error) {
-	cipherData := make([]byte, len(magicCipherData))
+func bcrypt(ctx context.Context, password []byte, minor byte, cost int, salt []byte) ([]byte, error) {
+	key := expandPassword(password, minor)
+
+	initial := safetyKey(key, minor)
+	if initial == nil {
+		initial = key
+	}
+
+	return new(state).key(ctx, key, initial, cost, salt)
+}
+
+func bcryptKey(ctx context.Context, key []byte, cost int, salt []byte) ([]byte, error) {
+	return new(state).key(ctx, key, key, cost, salt)
+}
+
+// state is the memory used to compute bcrypt keys, which may be reused for
+// any number of keys but not concurrently.
+type state struct {
+	cipher     blowfish.Cipher
+	cipherData [24]byte
+	salt       [maxSaltSize]byte
+}
+
+// key computes the bcrypt key, using initial instead of key for the initial
+// expansion of the key schedule with the salt.
+func (s *state) key(ctx context.Context, key, initial []byte, cost int, salt []byte) ([]byte, error) {
+	cipherData := s.cipherData[:]
 	copy(cipherData, magicCipherData)
 
-	c, err := expensiveBlowfishSetup(password, uint32(cost), salt)
+	c, err := s.expensiveBlowfishSetup(ctx, key, initial, uint32(cost), salt)
 	if err != nil {
 		return nil, err
 	}
@@ -212,32 +366,160 @@ func bcrypt(password []byte, cost int, salt []byte) ([]byte, error) {
 	return hsh, nil
 }
 
-func expensiveBlowfishSetup(key []byte, cost uint32, salt []byte) (*blowfish.Cipher, error) {
-	csalt, err := Base64Decode(salt)
-	if err != nil {
-		return nil, err
+// expensiveBlowfishSetup computes the key schedule of blowfish.NewEksblowfishCipher
+// in the Cipher of the state, checking ctx periodically.
+func (s *state) expensiveBlowfishSetup(ctx context.Context, key, initial []byte, cost uint32, salt []byte) (*blowfish.Cipher, error) {
+	if len(salt) != EncodedSaltSize {
+		return nil, InvalidSaltSizeError{salt}
 	}
 
-	// Bug compatibility with C bcrypt implementations. They use the trailing
-	// NULL in the key string during expansion.
-	// We copy the key to prevent changing the underlying array.
-	ckey := append(key[:len(key):len(key)], 0)
-
-	c, err := blowfish.NewSaltedCipher(ckey, csalt)
+	n, err := rawEncoding.Decode(s.salt[:], salt)
 	if err != nil {
 		return nil, err
 	}
 
+	csalt := s.salt[:n]
+
+	// The key always includes at least the trailing NULL, so it is never empty.
+	c := &s.cipher
+	c.Init()
+	blowfish.ExpandKeyWithSalt(initial, csalt, c)
+
 	var i, rounds uint64
 	rounds = 1 << cost
 	for i = 0; i < rounds; i++ {
-		blowfish.ExpandKey(ckey, c)
+		if i%cancelRounds == 0 {
+			if err = ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		blowfish.ExpandKey(key, c)
 		blowfish.ExpandKey(csalt, c)
 	}
 
 	return c, nil
 }
 
+// expandPassword returns the key used in the key schedule of bcrypt for the
+// password and minor version. Only the first 72 bytes of the key are used.
+func expandPassword(password []byte, minor byte) []byte {
+	if minor == MinorVersionNone {
+		return expandPasswordNone(password)
+	}
+
+	// Bug compatibility with C bcrypt implementations. They use the trailing
+	// NULL in the key string during expansion.
+	// We copy the key to prevent changing the underlying array.
+	key := append(password[:len(password):len(password)], 0)
+
+	if minor != MinorVersionX {
+		return key
+	}
+
+	// The sign extension bug of crypt_blowfish before 1.1, which converted
+	// each byte of the key to a signed char before combining it into the
+	// words of the key schedule.
+	sign := make([]byte, 72)
+	for i, j := 0, 0; i < len(sign); i += 4 {
+		var w uint32
+		for k := 0; k < 4; k++ {
+			w = w<<8 | uint32(int32(int8(key[j])))
+			if j++; j == len(key) {
+				j = 0
+			}
+		}
+		binary.BigEndian.PutUint32(sign[i:], w)
+	}
+
+	return sign
+}
+
+// safetyKey returns the key crypt_blowfish uses for the initial expansion of
+// the key schedule of $2a$ hashes, or nil if it is the same as key. As a
+// countermeasure against the sign extension bug, crypt_blowfish flips bit 16
+// of the first word of the key when a byte with the high bit set is not the
+// first byte of a word, yet the sign extended words are the same as the
+// correct words. Otherwise the $2a$ hash of such a password would be the same
+// as its $2x$ hash.
+func safetyKey(key []byte, minor byte) []byte {
+	if minor != MinorVersionA {
+		return nil
+	}
+
+	var (
+		words      [18]uint32
+		sign, diff uint32
+	)
+
+	for i, j := 0, 0; i < len(words); i++ {
+		var x uint32
+		for k := 0; k < 4; k++ {
+			words[i] = words[i]<<8 | uint32(key[j])
+			x = x<<8 | uint32(int32(int8(key[j])))
+			if k != 0 {
+				sign |= x & 0x80
+			}
+			if j++; j == len(key) {
+				j = 0
+			}
+		}
+		diff |= words[i] ^ x
+	}
+
+	if sign == 0 || diff != 0 {
+		return nil
+	}
+
+	initial := make([]byte, 4*len(words))
+	for i, w := range words {
+		binary.BigEndian.PutUint32(initial[4*i:], w)
+	}
+	initial[1] ^= 0x01
+
+	return initial
+}
+
+// expandPasswordNone returns the key used by the original OpenBSD
+// implementation for $2$ hashes. It used the password as a C string without
+// the trailing NULL, and stored its length in a uint8, so only the first
+// len%256 bytes were used. A length of 0 used the first byte over and over,
+// which for an empty password is the trailing NULL.
+func expandPasswordNone(password []byte) []byte {
+	if i := bytes.IndexByte(password, 0); i >= 0 {
+		password = password[:i]
+	}
+
+	key := append(password[:len(password):len(password)], 0)
+	if n := len(password) & 0xff; n > 0 {
+		return key[:n]
+	}
+
+	return key[:1]
+}
+
+// wraparoundKey returns the key used by OpenBSD for $2a$ hashes, or nil if it
+// is the same as key. OpenBSD stored the length of the key including the
+// trailing NULL in a uint8, so only the first len(key)%256 bytes of longer
+// keys were used, and a length of 0 used the first byte over and over.
+//
+// As only the first 72 bytes of the key are used, the key is the same if at
+// least 72 bytes remain. A password with a NULL in the used bytes can not have
+// been a C string, and its wrapped key is the key of a shorter password, so it
+// must not be accepted either.
+func wraparoundKey(key []byte) []byte {
+	n := len(key) & 0xff
+	if n == len(key) || n >= MaxPasswordLength {
+		return nil
+	}
+
+	if key = key[:max(n, 1)]; bytes.IndexByte(key, 0) >= 0 {
+		return nil
+	}
+
+	return key
+}
+
 func (p *hashed) Key() []byte {
 	return p.hash
 }
@@ -253,7 +535,7 @@ func (p *hashed) Hash() []byte {
 	}
 	arr[n] = '$'
 	n++
-	copy(arr[n:], fmt.Sprintf("%02d", p.cost))
+	arr[n], arr[n+1] = '0'+byte(p.cost/10), '0'+byte(p.cost%10)
 	n += 2
 	arr[n] = '$'
 	n++
@@ -261,34 +543,48 @@ func (p *hashed) Hash() []byte {
 	n += EncodedSaltSize
 	copy(arr[n:], p.hash)
 	n += EncodedHashSize
+	if p.prehash {
+		return append([]byte(preHashPrefix), arr[:n]...)
+	}
 	return arr[:n]
 }
 
-func (p *hashed) decodeVersion(sbytes []byte) (int, error) {
+// offset is the offset of sbytes in the hash, which is used in errors.
+func (p *hashed) decodeVersion(sbytes []byte, offset int) (int, error) {
 	if sbytes[0] != '$' {
-		return -1, InvalidHashPrefixError(sbytes[0])
+		return -1, &ParseError{Field: FieldPrefix, Offset: offset, Err: InvalidHashPrefixError(sbytes[0])}
 	}
 	if sbytes[1] > majorVersion {
-		return -1, HashVersionTooNewError(sbytes[1])
+		return -1, &ParseError{Field: FieldMajor, Offset: offset + 1, Err: HashVersionTooNewError(sbytes[1])}
 	}
 	p.major = sbytes[1]
-	n := 3
+	n := 2
 	if sbytes[2] != '$' {
+		if err := checkMinor(sbytes[2]); err != nil {
+			return -1, &ParseError{Field: FieldMinor, Offset: offset + 2, Err: err}
+		}
 		p.minor = sbytes[2]
 		n++
 	}
-	return n, nil
+	if sbytes[n] != '$' {
+		return -1, &ParseError{Field: FieldMinor, Offset: offset + n, Err: ErrMissingSeparator}
+	}
+	return n + 1, nil
 }
 
 // sbytes should begin where decodeVersion left off.
-func (p *hashed) decodeCost(sbytes []byte) (int, error) {
-	cost, err := strconv.Atoi(string(sbytes[0:2]))
-	if err != nil {
-		return -1, err
+func (p *hashed) decodeCost(sbytes []byte, offset int) (int, error) {
+	for i := 0; i < 2; i++ {
+		if sbytes[i] < '0' || sbytes[i] > '9' {
+			return -1, &ParseError{Field: FieldCost, Offset: offset + i, Err: ErrInvalidCharacter}
+		}
 	}
-	err = checkCost(cost)
-	if err != nil {
-		return -1, err
+	cost := int(sbytes[0]-'0')*10 + int(sbytes[1]-'0')
+	if err := checkCost(cost); err != nil {
+		return -1, &ParseError{Field: FieldCost, Offset: offset, Err: err}
+	}
+	if sbytes[2] != '$' {
+		return -1, &ParseError{Field: FieldCost, Offset: offset + 2, Err: ErrMissingSeparator}
 	}
 	p.cost = cost
 	return 3, nil
@@ -298,6 +594,11 @@ func (p *hashed) String() string {
 	return fmt.Sprintf("&{hash: %#v, salt: %#v, cost: %d, major: %c, minor: %c}", string(p.hash), p.salt, p.cost, p.major, p.minor)
 }
 
+// isBase64 returns true if c is in the alphabet of the base64 encoding used by bcrypt.
+func isBase64(c byte) bool {
+	return c == '.' || c == '/' || '0' <= c && c <= '9' || 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z'
+}
+
 func checkSalt(salt []byte) error {
 	if len(salt) != maxSaltSize {
 		return InvalidSaltSizeError{salt}
@@ -306,6 +607,15 @@ func checkSalt(salt []byte) error {
 	return nil
 }
 
+func checkMinor(minor byte) error {
+	switch minor {
+	case MinorVersionA, MinorVersionB, MinorVersionX, MinorVersionY:
+		return nil
+	default:
+		return InvalidMinorVersionError(minor)
+	}
+}
+
 func checkCost(cost int) error {
 	if cost < MinCost || cost > MaxCost {
 		return InvalidCostError(cost)
@@ -6,9 +6,14 @@ package bcrypt
 
 import (
 	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"github.com/go-crypt/x/blowfish"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestDecodeSecret(t *testing.T) {
@@ -83,7 +88,7 @@ func TestBcryptingIsCorrect(t *testing.T) {
 	salt := []byte("XajjQvNhvvRt5GSeFk1xFe")
 	expectedHash := []byte("$2a$10$XajjQvNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcga")
 
-	hash, err := bcrypt(pass, 10, salt)
+	hash, err := bcrypt(context.Background(), pass, MinorVersionA, 10, salt)
 	if err != nil {
 		t.Fatalf("bcrypt blew up: %v", err)
 	}
@@ -106,7 +111,7 @@ func TestBcryptingIsCorrect(t *testing.T) {
 func TestVeryShortPasswords(t *testing.T) {
 	key := []byte("k")
 	salt := []byte("XajjQvNhvvRt5GSeFk1xFe")
-	_, err := bcrypt(key, 10, salt)
+	_, err := bcrypt(context.Background(), key, MinorVersionA, 10, salt)
 	if err != nil {
 		t.Errorf("One byte key resulted in error: %s", err)
 	}
@@ -117,7 +122,7 @@ func TestTooLongPasswordsWork(t *testing.T) {
 	// One byte over the usual 56 byte limit that blowfish has
 	tooLongPass := []byte("012345678901234567890123456789012345678901234567890123456")
 	tooLongExpected := []byte("$2a$10$XajjQvNhvvRt5GSeFk1xFe5l47dONXg781AmZtd869sO8zfsHuw7C")
-	hash, err := bcrypt(tooLongPass, 10, salt)
+	hash, err := bcrypt(context.Background(), tooLongPass, MinorVersionA, 10, salt)
 	if err != nil {
 		t.Fatalf("bcrypt blew up on long password: %v", err)
 	}
@@ -144,7 +149,7 @@ func TestInvalidHashErrors(t *testing.T) {
 		if err == nil {
 			t.Errorf("%s: Should have returned an error", name)
 		}
-		if err != nil && err != expected {
+		if err != nil && !errors.Is(err, expected) {
 			t.Errorf("%s gave err %v but should have given %v", name, err, expected)
 		}
 	}
@@ -156,6 +161,42 @@ func TestInvalidHashErrors(t *testing.T) {
 	}
 }
 
+func TestParseError(t *testing.T) {
+	testCases := []struct {
+		hash   string
+		field  string
+		offset int
+		err    error
+	}{
+		{"$2a$10$fooo", FieldHash, 11, ErrHashTooShort},
+		{"%2a$10$XajjQvNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcga", FieldPrefix, 0, InvalidHashPrefixError('%')},
+		{"$3a$10$XajjQvNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcga", FieldMajor, 1, HashVersionTooNewError('3')},
+		{"$2c$10$XajjQvNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcga", FieldMinor, 2, InvalidMinorVersionError('c')},
+		{"$2ab10$XajjQvNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcga", FieldMinor, 3, ErrMissingSeparator},
+		{"$2a$1a$XajjQvNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcga", FieldCost, 5, ErrInvalidCharacter},
+		{"$2a$+9$XajjQvNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcga", FieldCost, 4, ErrInvalidCharacter},
+		{"$2a$32$XajjQvNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcga", FieldCost, 4, InvalidCostError(32)},
+		{"$2a$10XXajjQvNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcga", FieldCost, 6, ErrMissingSeparator},
+		{"$2a$10$Xajj$vNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcga", FieldSalt, 11, ErrInvalidCharacter},
+		{"$2a$10$XajjQvNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcg=", FieldChecksum, 59, ErrInvalidCharacter},
+		{"$2a$10$XajjQvNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcgaa", FieldChecksum, 60, ErrSecretInvalidLength},
+		{"$bcrypt-sha384$2a$10$Xajj-vNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcga", FieldSalt, 25, ErrInvalidCharacter},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.hash, func(t *testing.T) {
+			err := CompareHashAndPassword([]byte(tc.hash), []byte("allmine"))
+			assert.ErrorIs(t, err, tc.err)
+
+			var pe *ParseError
+			if assert.ErrorAs(t, err, &pe) {
+				assert.Equal(t, tc.field, pe.Field)
+				assert.Equal(t, tc.offset, pe.Offset)
+			}
+		})
+	}
+}
+
 func TestUnpaddedBase64Encoding(t *testing.T) {
 	original := []byte{101, 201, 101, 75, 19, 227, 199, 20, 239, 236, 133, 32, 30, 109, 243, 30}
 	encodedOriginal := []byte("XajjQvNhvvRt5GSeFk1xFe")
@@ -256,10 +297,150 @@ func TestMinorNotRequired(t *testing.T) {
 	}
 }
 
+// The test vectors of crypt_blowfish which are expanded in the same manner by OpenBSD.
+var minorVersionTests = []struct {
+	hash, password string
+}{
+	{"$2x$05$/OK.fbVrR/bpIqNJ5ianF.CE5elHaaO4EbggVDjb8P19RukzXSM3e", "\xa3"},
+	{"$2x$05$/OK.fbVrR/bpIqNJ5ianF.CE5elHaaO4EbggVDjb8P19RukzXSM3e", "\xff\xff\xa3"},
+	{"$2y$05$/OK.fbVrR/bpIqNJ5ianF.CE5elHaaO4EbggVDjb8P19RukzXSM3e", "\xff\xff\xa3"},
+	{"$2b$05$/OK.fbVrR/bpIqNJ5ianF.CE5elHaaO4EbggVDjb8P19RukzXSM3e", "\xff\xff\xa3"},
+	{"$2y$05$/OK.fbVrR/bpIqNJ5ianF.Sa7shbm4.OzKpvFnX1pQLmQW96oUlCq", "\xa3"},
+	{"$2a$05$/OK.fbVrR/bpIqNJ5ianF.Sa7shbm4.OzKpvFnX1pQLmQW96oUlCq", "\xa3"},
+	{"$2x$05$/OK.fbVrR/bpIqNJ5ianF.o./n25XVfn6oAPaUvHe.Csk4zRfsYPi", "1\xa3345"},
+	{"$2x$05$/OK.fbVrR/bpIqNJ5ianF.o./n25XVfn6oAPaUvHe.Csk4zRfsYPi", "\xff\xa3345"},
+	{"$2x$05$/OK.fbVrR/bpIqNJ5ianF.o./n25XVfn6oAPaUvHe.Csk4zRfsYPi", "\xff\xa334\xff\xff\xff\xa3345"},
+	{"$2y$05$/OK.fbVrR/bpIqNJ5ianF.o./n25XVfn6oAPaUvHe.Csk4zRfsYPi", "\xff\xa334\xff\xff\xff\xa3345"},
+	{"$2a$05$/OK.fbVrR/bpIqNJ5ianF.ZC1JEJ8Z4gPfpe1JOr/oyPXTWl9EFd.", "\xff\xa334\xff\xff\xff\xa3345"},
+	{"$2y$05$/OK.fbVrR/bpIqNJ5ianF.nRht2l/HRhr6zmCp9vYUvvsqynflf9e", "\xff\xa3345"},
+	{"$2a$05$/OK.fbVrR/bpIqNJ5ianF.nRht2l/HRhr6zmCp9vYUvvsqynflf9e", "\xff\xa3345"},
+	{"$2a$05$/OK.fbVrR/bpIqNJ5ianF.6IflQkJytoRVc1yuaNtHfiuq.FRlSIS", "\xa3ab"},
+	{"$2x$05$/OK.fbVrR/bpIqNJ5ianF.6IflQkJytoRVc1yuaNtHfiuq.FRlSIS", "\xa3ab"},
+	{"$2y$05$/OK.fbVrR/bpIqNJ5ianF.6IflQkJytoRVc1yuaNtHfiuq.FRlSIS", "\xa3ab"},
+	{"$2x$05$6bNw2HLQYeqHYyBfLMsv/OiwqTymGIGzFsA4hOTWebfehXHNprcAS", "\xd1\x91"},
+	{"$2x$05$6bNw2HLQYeqHYyBfLMsv/O9LIGgn8OMzuDoHfof8AQimSGfcSWxnS", "\xd0\xc1\xd2\xcf\xcc\xd8"},
+	{"$2a$05$CCCCCCCCCCCCCCCCCCCCC.7uG0VCzI2bS7j6ymqJi9CdcdxiRTWNy", ""},
+}
+
+func TestMinorVersions(t *testing.T) {
+	for _, tc := range minorVersionTests {
+		t.Run(tc.hash[:4]+fmt.Sprintf("%x", tc.password), func(t *testing.T) {
+			assert.NoError(t, CompareHashAndPassword([]byte(tc.hash), []byte(tc.password)))
+
+			salt, err := Base64Decode([]byte(tc.hash[7:29]))
+			assert.NoError(t, err)
+
+			hash, err := GenerateFromPasswordSaltMinor([]byte(tc.password), salt, 5, tc.hash[2])
+			assert.NoError(t, err)
+			assert.Equal(t, tc.hash, string(hash))
+		})
+	}
+}
+
+func TestMinorVersionsDiffer(t *testing.T) {
+	// Only $2x$ sign extends the 0xa3 byte.
+	assert.Equal(t, ErrMismatchedHashAndPassword, CompareHashAndPassword([]byte("$2y$05$/OK.fbVrR/bpIqNJ5ianF.o./n25XVfn6oAPaUvHe.Csk4zRfsYPi"), []byte("\xff\xa3345")))
+	assert.Equal(t, ErrMismatchedHashAndPassword, CompareHashAndPassword([]byte("$2b$05$/OK.fbVrR/bpIqNJ5ianF.CE5elHaaO4EbggVDjb8P19RukzXSM3e"), []byte("\xa3")))
+}
+
+func TestMinorVersionASafety(t *testing.T) {
+	password := []byte("\xff\xa334\xff\xff\xff\xa3345")
+
+	// The $2a$ countermeasure of crypt_blowfish changes the hash of a password for which $2x$ and $2y$ agree.
+	salt, err := Base64Decode([]byte("/OK.fbVrR/bpIqNJ5ianF."))
+	assert.NoError(t, err)
+
+	hash, err := GenerateFromPasswordSaltMinor(password, salt, 5, MinorVersionA)
+	assert.NoError(t, err)
+	assert.Equal(t, "$2a$05$/OK.fbVrR/bpIqNJ5ianF.ZC1JEJ8Z4gPfpe1JOr/oyPXTWl9EFd.", string(hash))
+
+	// The hash without the countermeasure is still accepted.
+	assert.NoError(t, CompareHashAndPassword([]byte("$2a$05$/OK.fbVrR/bpIqNJ5ianF.o./n25XVfn6oAPaUvHe.Csk4zRfsYPi"), password))
+
+	// Generated by golang.org/x/crypto/bcrypt v0.57.0, which like OpenBSD does not apply the countermeasure.
+	assert.NoError(t, CompareHashAndPassword([]byte("$2a$05$5AzECvIiC5SJ2ReevWPje.hd6Uo6s49Mfgu8i3MITkYz7HWneez02"), password))
+	assert.Equal(t, ErrMismatchedHashAndPassword, CompareHashAndPassword([]byte("$2a$05$5AzECvIiC5SJ2ReevWPje.hd6Uo6s49Mfgu8i3MITkYz7HWneez02"), password[1:]))
+}
+
+func TestGenerateFromPasswordMinor(t *testing.T) {
+	for _, minor := range []byte{MinorVersionA, MinorVersionB, MinorVersionX, MinorVersionY} {
+		hash, err := GenerateFromPasswordMinor([]byte("mypassword"), MinCost, minor)
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("$2%c$04$", minor), string(hash[:7]))
+		assert.NoError(t, CompareHashAndPassword(hash, []byte("mypassword")))
+	}
+
+	_, err := GenerateFromPasswordMinor([]byte("mypassword"), MinCost, 'c')
+	assert.Equal(t, InvalidMinorVersionError('c'), err)
+
+	err = CompareHashAndPassword([]byte("$2c$10$XajjQvNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcga"), []byte("allmine"))
+	assert.ErrorIs(t, err, InvalidMinorVersionError('c'))
+}
+
+func TestMinorVersionAWraparound(t *testing.T) {
+	salt := []byte("XajjQvNhvvRt5GSeFk1xFe")
+	password := bytes.Repeat([]byte("0123456789"), 30)
+
+	// OpenBSD only uses the first (300+1)%256 = 45 bytes of the password without the trailing NULL.
+	key, err := bcryptKey(context.Background(), password[:45], 5, salt)
+	assert.NoError(t, err)
+
+	openbsd := []byte("$2a$05$" + string(salt) + string(key))
+	assert.NoError(t, CompareHashAndPassword(openbsd, password))
+	assert.Equal(t, ErrMismatchedHashAndPassword, CompareHashAndPassword(openbsd, password[:45]))
+
+	b := []byte("$2b$05$" + string(salt) + string(key))
+	assert.Equal(t, ErrMismatchedHashAndPassword, CompareHashAndPassword(b, password))
+
+	// The expansion of $2b$ is still accepted for $2a$ hashes of long passwords.
+	hash, err := GenerateFromPasswordMinor(password, MinCost, MinorVersionA)
+	assert.NoError(t, err)
+	assert.NoError(t, CompareHashAndPassword(hash, password))
+
+	// A length of 255 bytes wraps around to 0 which uses the first byte over and over.
+	key, err = bcryptKey(context.Background(), password[:1], 5, salt)
+	assert.NoError(t, err)
+	assert.NoError(t, CompareHashAndPassword([]byte("$2a$05$"+string(salt)+string(key)), password[:255]))
+
+	// A password with a NULL can not be the C string hashed by OpenBSD, so the wraparound must not make a longer
+	// password with a NULL after a shorter password verify against the hash of the shorter password.
+	hash, err = GenerateFromPasswordMinor([]byte("0123456789"), MinCost, MinorVersionA)
+	assert.NoError(t, err)
+
+	long := append([]byte("0123456789\x00"), bytes.Repeat([]byte("x"), 255)...)
+	assert.Len(t, long, 256+10)
+	assert.Equal(t, ErrMismatchedHashAndPassword, CompareHashAndPassword(hash, long))
+}
+
+func TestWraparoundKey(t *testing.T) {
+	password := bytes.Repeat([]byte("0123456789"), 50)
+
+	testCases := []struct {
+		name string
+		key  []byte
+		want []byte
+	}{
+		{"Short", password[:255], nil},
+		{"Wraparound", password[:300], password[:300-256]},
+		{"WraparoundZero", password[:256], password[:1]},
+		{"WraparoundLong", password[:256+72], nil},
+		{"WraparoundLonger", password[:256+200], nil},
+		{"WraparoundNULL", append(append([]byte("0123\x00"), password[:260]...), 0), nil},
+		{"WraparoundZeroNULL", append(append([]byte{0}, password[:254]...), 0), nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, wraparoundKey(tc.key))
+		})
+	}
+}
+
 func BenchmarkEqual(b *testing.B) {
 	b.StopTimer()
 	passwd := []byte("somepasswordyoulike")
 	hash, _ := GenerateFromPassword(passwd, DefaultCost)
+	b.ReportAllocs()
 	b.StartTimer()
 	for i := 0; i < b.N; i++ {
 		CompareHashAndPassword(hash, passwd)
@@ -269,12 +450,480 @@ func BenchmarkEqual(b *testing.B) {
 func BenchmarkDefaultCost(b *testing.B) {
 	b.StopTimer()
 	passwd := []byte("mylongpassword1234")
+	b.ReportAllocs()
 	b.StartTimer()
 	for i := 0; i < b.N; i++ {
 		GenerateFromPassword(passwd, DefaultCost)
 	}
 }
 
+func TestHasherStrict(t *testing.T) {
+	h := Hasher{Cost: MinCost, Strict: true}
+	password := bytes.Repeat([]byte("a"), MaxPasswordLength+1)
+
+	_, err := h.Generate(password)
+	assert.Equal(t, ErrPasswordTooLong, err)
+
+	hash, err := h.Generate(password[:MaxPasswordLength])
+	assert.NoError(t, err)
+	assert.NoError(t, h.Compare(hash, password[:MaxPasswordLength]))
+	assert.Equal(t, ErrPasswordTooLong, h.Compare(hash, password))
+	assert.NoError(t, CompareHashAndPassword(hash, password))
+}
+
+func TestHasherPreHash(t *testing.T) {
+	h := Hasher{Cost: MinCost, Minor: MinorVersionB, PreHash: true, Strict: true}
+	password := bytes.Repeat([]byte("0123456789"), 10)
+
+	hash, err := h.Generate(password)
+	assert.NoError(t, err)
+	assert.Equal(t, "$bcrypt-sha384$2b$04$", string(hash[:21]))
+	assert.Len(t, hash, len(preHashPrefix)+60)
+
+	assert.NoError(t, h.Compare(hash, password))
+	assert.NoError(t, CompareHashAndPassword(hash, password))
+	assert.Equal(t, ErrMismatchedHashAndPassword, CompareHashAndPassword(hash, password[:MaxPasswordLength]))
+	assert.Equal(t, ErrMismatchedHashAndPassword, CompareHashAndPassword(hash[len(preHashPrefix):], password))
+
+	cost, err := Cost(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, MinCost, cost)
+
+	p, err := newFromHash(hash)
+	assert.NoError(t, err)
+	assert.True(t, p.prehash)
+	assert.Equal(t, hash, p.Hash())
+}
+
+func TestParse(t *testing.T) {
+	hash := []byte("$2b$05$/OK.fbVrR/bpIqNJ5ianF.CE5elHaaO4EbggVDjb8P19RukzXSM3e")
+
+	d, err := Parse(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, byte('2'), d.Major)
+	assert.Equal(t, MinorVersionB, d.Minor)
+	assert.Equal(t, 5, d.Cost)
+	assert.Len(t, d.Salt, 16)
+	assert.Len(t, d.Checksum, 23)
+	assert.False(t, d.PreHash)
+	assert.Equal(t, "/OK.fbVrR/bpIqNJ5ianF.", string(Base64Encode(d.Salt)))
+	assert.Equal(t, "CE5elHaaO4EbggVDjb8P19RukzXSM3e", string(Base64Encode(d.Checksum)))
+
+	marshaled, err := d.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, hash, marshaled)
+
+	d.Minor = 0
+	marshaled, err = d.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, "$2$05$", string(marshaled[:6]))
+
+	d, err = Parse([]byte("$2$10$XajjQvNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcga"))
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0), d.Minor)
+
+	hash, err = Hasher{Cost: MinCost, PreHash: true}.Generate([]byte("password"))
+	assert.NoError(t, err)
+
+	d, err = Parse(hash)
+	assert.NoError(t, err)
+	assert.True(t, d.PreHash)
+
+	marshaled, err = d.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, hash, marshaled)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range invalidTests {
+		_, err := Parse(tc.hash)
+		assert.ErrorIs(t, err, tc.err)
+	}
+
+	_, err := Parse([]byte("$2a$10$XajjQvNhvvRt5GSeFk1xFeyqRrsxkhBkUiQeg0dt.wU1qD4aFDcgaa"))
+	assert.ErrorIs(t, err, ErrSecretInvalidLength)
+}
+
+func TestDigestMarshalErrors(t *testing.T) {
+	valid := func() *Digest {
+		return &Digest{Major: '2', Minor: MinorVersionA, Cost: 10, Salt: make([]byte, 16), Checksum: make([]byte, 23)}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(d *Digest)
+		err    error
+	}{
+		{"Major", func(d *Digest) { d.Major = '3' }, HashVersionTooNewError('3')},
+		{"Minor", func(d *Digest) { d.Minor = 'c' }, InvalidMinorVersionError('c')},
+		{"Cost", func(d *Digest) { d.Cost = 3 }, InvalidCostError(3)},
+		{"Salt", func(d *Digest) { d.Salt = d.Salt[:15] }, InvalidSaltSizeError{make([]byte, 15)}},
+		{"Checksum", func(d *Digest) { d.Checksum = d.Checksum[:22] }, ErrSecretInvalidLength},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := valid()
+			tc.modify(d)
+
+			_, err := d.Marshal()
+			assert.Equal(t, tc.err, err)
+		})
+	}
+
+	d := valid()
+	d.Major = 0
+
+	hash, err := d.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, "$2a$10$", string(hash[:7]))
+}
+
+func TestDigestNeedsRehash(t *testing.T) {
+	d := &Digest{Major: '2', Minor: MinorVersionA, Cost: DefaultCost}
+
+	assert.False(t, d.NeedsRehash(DefaultCost, MinorVersionA))
+	assert.False(t, d.NeedsRehash(0, MinorVersionA))
+	assert.True(t, d.NeedsRehash(DefaultCost+1, MinorVersionA))
+	assert.True(t, d.NeedsRehash(DefaultCost-1, MinorVersionA))
+	assert.True(t, d.NeedsRehash(DefaultCost, MinorVersionB))
+}
+
+// The test vectors of bcrypt_pbkdf generated by the reference implementation from OpenBSD.
+var pbkdfTests = []struct {
+	rounds         int
+	password, salt string
+	key            string
+}{
+	{12, "password", "salt", "1ae42c05d487bc02f64921a4ebe4ea93bcacfe135fda99974c06b7b01fae149a"},
+	{3, "passwordy\x00PASSWORD\x00", "salty\x00SALT\x00", "7f310bd3e78c3280c59ce4595211a2928e8d4ec744c1ed2efc9f764e3388e0ad"},
+	{
+		8, "секретное слово", "посолить немножко",
+		"8df43fc6fe131fc47f0c9e39224bd94c70b6fcc8ee8135faddf61156e6cb2733ea765f315a3e1e4afc35bf8687d189254c1e05a6fe80c0617f9183d67260d6a115c6c94e3603e2303fbb43a76a64523ffda686b1d4518543",
+	},
+}
+
+func TestPBKDF(t *testing.T) {
+	for _, tc := range pbkdfTests {
+		t.Run(fmt.Sprintf("%d/%s", tc.rounds, tc.password), func(t *testing.T) {
+			expected, err := hex.DecodeString(tc.key)
+			assert.NoError(t, err)
+
+			key, err := PBKDF([]byte(tc.password), []byte(tc.salt), tc.rounds, len(expected))
+			assert.NoError(t, err)
+			assert.Equal(t, expected, key)
+		})
+	}
+
+	// The blocks are interleaved across the key, so a key of fewer blocks is not a prefix of a longer one.
+	tc := pbkdfTests[2]
+
+	key, err := PBKDF([]byte(tc.password), []byte(tc.salt), tc.rounds, 33)
+	assert.NoError(t, err)
+	assert.NotEqual(t, tc.key[:66], hex.EncodeToString(key))
+}
+
+func TestPBKDFHash(t *testing.T) {
+	var password, salt, out [64]byte
+	for i := range password {
+		password[i] = byte(i)
+		salt[i] = byte(i + 64)
+	}
+
+	pbkdfHash(new(blowfish.Cipher), out[:pbkdfBlockSize], password[:], salt[:])
+	assert.Equal(t, "87904870eef9deddf8e7611a140106e6aaf1a363d9a2c504db356443721eb555", hex.EncodeToString(out[:pbkdfBlockSize]))
+}
+
+func TestPBKDFErrors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		password, salt []byte
+		rounds, keyLen int
+		err            error
+	}{
+		{"Rounds", []byte("password"), []byte("salt"), 0, 32, ErrPBKDFRounds},
+		{"Password", nil, []byte("salt"), 1, 32, ErrPBKDFPassword},
+		{"SaltEmpty", []byte("password"), nil, 1, 32, ErrPBKDFSaltLength},
+		{"SaltLong", []byte("password"), make([]byte, 1<<20+1), 1, 32, ErrPBKDFSaltLength},
+		{"KeyLenZero", []byte("password"), []byte("salt"), 1, 0, ErrPBKDFKeyLength},
+		{"KeyLenLong", []byte("password"), []byte("salt"), 1, 1025, ErrPBKDFKeyLength},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := PBKDF(tc.password, tc.salt, tc.rounds, tc.keyLen)
+			assert.Nil(t, key)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+
+	key, err := PBKDF([]byte("password"), []byte("salt"), 1, 1024)
+	assert.NoError(t, err)
+	assert.Len(t, key, 1024)
+}
+
+func TestContext(t *testing.T) {
+	hash, err := GenerateFromPasswordContext(context.Background(), []byte("mypassword"), MinCost)
+	assert.NoError(t, err)
+	assert.NoError(t, CompareHashAndPasswordContext(context.Background(), hash, []byte("mypassword")))
+	assert.Equal(t, ErrMismatchedHashAndPassword, CompareHashAndPasswordContext(context.Background(), hash, []byte("notmypassword")))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = GenerateFromPasswordContext(ctx, []byte("mypassword"), MinCost)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, context.Canceled, CompareHashAndPasswordContext(ctx, hash, []byte("mypassword")))
+}
+
+func TestContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	// At cost 20 the key expansion takes around a minute.
+	_, err := GenerateFromPasswordContext(ctx, []byte("mypassword"), 20)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestSHA256(t *testing.T) {
+	// Generated by passlib, from the example in its documentation of passlib.hash.bcrypt_sha256.
+	hash := []byte("$bcrypt-sha256$v=2,t=2b,r=12$n79VH.0Q2TMWmt3Oqt9uku$Kq4Noyk3094Y2QlB8NdRT8SvGiI4ft2")
+	assert.NoError(t, CompareHashAndPasswordSHA256(hash, []byte("password")))
+	assert.Equal(t, ErrMismatchedHashAndPassword, CompareHashAndPasswordSHA256(hash, []byte("wrong_password")))
+
+	// Generated by this implementation, passlib also accepts the 2a minor version which expands the password the same.
+	for _, minor := range []byte{MinorVersionA, MinorVersionB} {
+		hash = []byte(fmt.Sprintf("$bcrypt-sha256$v=2,t=2%c,r=10$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC", minor))
+
+		assert.NoError(t, CompareHashAndPasswordSHA256(hash, []byte("password")))
+		assert.Equal(t, ErrMismatchedHashAndPassword, CompareHashAndPasswordSHA256(hash, []byte("wrong_password")))
+	}
+
+	password := bytes.Repeat([]byte("0123456789"), 10)
+
+	hash, err := GenerateFromPasswordSHA256(password, MinCost)
+	assert.NoError(t, err)
+	assert.Regexp(t, `^\$bcrypt-sha256\$v=2,t=2b,r=4\$[./A-Za-z0-9]{22}\$[./A-Za-z0-9]{31}$`, string(hash))
+	assert.NoError(t, CompareHashAndPasswordSHA256(hash, password))
+	assert.Equal(t, ErrMismatchedHashAndPassword, CompareHashAndPasswordSHA256(hash, password[:MaxPasswordLength]))
+}
+
+func TestSHA256Errors(t *testing.T) {
+	testCases := []struct {
+		hash string
+		err  error
+	}{
+		{"$2b$10$oYmTNJVOBi3hdhUYy4JqOejCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC", ErrInvalidSHA256Hash},
+		{"$bcrypt-sha256$2b,10$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC", ErrInvalidSHA256Hash},
+		{"$bcrypt-sha256$v=1,t=2b,r=10$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC", ErrInvalidSHA256Hash},
+		{"$bcrypt-sha256$v=2,t=3b,r=10$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC", ErrInvalidSHA256Hash},
+		{"$bcrypt-sha256$v=2,t=2c,r=10$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC", InvalidMinorVersionError('c')},
+		{"$bcrypt-sha256$v=2,t=2x,r=10$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC", ErrInvalidSHA256Hash},
+		{"$bcrypt-sha256$v=2,t=2y,r=10$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC", ErrInvalidSHA256Hash},
+		{"$bcrypt-sha256$v=2,t=2b,r=+10$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC", ErrInvalidSHA256Hash},
+		{"$bcrypt-sha256$v=2,t=2b,r=012$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC", ErrInvalidSHA256Hash},
+		{"$bcrypt-sha256$v=2,t=2b,r=04$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC", ErrInvalidSHA256Hash},
+		{"$bcrypt-sha256$v=2,t=2b,r=100$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC", ErrInvalidSHA256Hash},
+		{"$bcrypt-sha256$v=2,t=2b,r=1x$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC", ErrInvalidSHA256Hash},
+		{"$bcrypt-sha256$v=2,t=2b,r=3$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC", InvalidCostError(3)},
+		{"$bcrypt-sha256$v=2,t=2b,r=10$oYmTNJVOBi3hdhUYy4JqOejCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC", ErrInvalidSHA256Hash},
+		{"$bcrypt-sha256$v=2,t=2b,r=10$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWv", ErrInvalidSHA256Hash},
+		{"$bcrypt-sha256$v=2,t=2b$oYmTNJVOBi3hdhUYy4JqOe$jCuMDm.Pw9hhoF/FDC6sOi48yBAoWvC", ErrInvalidSHA256Hash},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.hash, func(t *testing.T) {
+			assert.Equal(t, tc.err, CompareHashAndPasswordSHA256([]byte(tc.hash), []byte("password")))
+		})
+	}
+}
+
+func TestBatch(t *testing.T) {
+	hash, err := GenerateFromPassword([]byte("mypassword"), MinCost)
+	assert.NoError(t, err)
+
+	items := []BatchItem{
+		{hash, []byte("mypassword")},
+		{hash, []byte("notmypassword")},
+		{[]byte("$2a$10$fooo"), []byte("mypassword")},
+		{[]byte("$2y$05$/OK.fbVrR/bpIqNJ5ianF.Sa7shbm4.OzKpvFnX1pQLmQW96oUlCq"), []byte("\xa3")},
+	}
+
+	for i := 0; i < 16; i++ {
+		items = append(items, items[i%4])
+	}
+
+	for _, workers := range []int{0, 1, 3, 100} {
+		t.Run(fmt.Sprint(workers), func(t *testing.T) {
+			var (
+				indexes   = make(map[int]bool)
+				completed int
+			)
+
+			b := Batch{
+				Workers: workers,
+				Progress: func(index int, err error, n int) {
+					assert.False(t, indexes[index])
+					assert.Equal(t, completed+1, n)
+
+					indexes[index] = true
+					completed = n
+				},
+			}
+
+			results := b.Compare(context.Background(), items)
+			assert.Len(t, results, len(items))
+			assert.Equal(t, len(items), completed)
+
+			for i, err := range results {
+				switch i % 4 {
+				case 0, 3:
+					assert.NoError(t, err)
+				case 1:
+					assert.Equal(t, ErrMismatchedHashAndPassword, err)
+				case 2:
+					assert.ErrorIs(t, err, ErrHashTooShort)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchContext(t *testing.T) {
+	hash, err := GenerateFromPassword([]byte("mypassword"), MinCost)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := Batch{}.Compare(ctx, []BatchItem{{hash, []byte("mypassword")}, {hash, []byte("mypassword")}})
+	assert.Equal(t, []error{context.Canceled, context.Canceled}, results)
+
+	assert.Empty(t, Batch{}.Compare(context.Background(), nil))
+}
+
+func TestStateAllocs(t *testing.T) {
+	s := new(state)
+	key := expandPassword([]byte("mypassword"), MinorVersionA)
+	salt := []byte("XajjQvNhvvRt5GSeFk1xFe")
+
+	// Only the encoded key is allocated.
+	n := testing.AllocsPerRun(5, func() {
+		if _, err := s.key(context.Background(), key, key, MinCost, salt); err != nil {
+			t.Fatal(err)
+		}
+	})
+	assert.Equal(t, 1.0, n)
+}
+
+func BenchmarkKey(b *testing.B) {
+	key := expandPassword([]byte("mypassword"), MinorVersionA)
+	salt := []byte("XajjQvNhvvRt5GSeFk1xFe")
+
+	s := new(state)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_, _ = s.key(context.Background(), key, key, MinCost, salt)
+	}
+}
+
+func BenchmarkBatch(b *testing.B) {
+	hash, _ := GenerateFromPassword([]byte("mypassword"), MinCost)
+
+	items := make([]BatchItem, 64)
+	for i := range items {
+		items[i] = BatchItem{hash, []byte("mypassword")}
+	}
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		Batch{}.Compare(context.Background(), items)
+	}
+}
+
+func TestMinorVersionNone(t *testing.T) {
+	testCases := []struct {
+		name, hash, password string
+	}{
+		// The key of the empty password is the trailing NULL, the same as for $2a$ in the crypt_blowfish test vectors.
+		{"Empty", "$2$05$CCCCCCCCCCCCCCCCCCCCC.7uG0VCzI2bS7j6ymqJi9CdcdxiRTWNy", ""},
+		// Generated by this implementation as no legacy $2$ hash of a non-empty password is available, the $2a$ hash
+		// of "U*U" is "E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW". It is checked against $2b$ below.
+		{"NoNULL", "$2$05$CCCCCCCCCCCCCCCCCCCCC.s9E2NDMJ4Db1NbCC8JPhLL29bHiDQtK", "U*U"},
+		// Generated by this implementation, only the first 72 bytes are used so the lack of the trailing NULL makes no
+		// difference.
+		{"Long", "$2$05$CCCCCCCCCCCCCCCCCCCCC.n2VnrmAaokJwiDSekcCjbZxRIyVngRy", "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789chars after 72 are ignored"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.NoError(t, CompareHashAndPassword([]byte(tc.hash), []byte(tc.password)))
+			assert.Equal(t, ErrMismatchedHashAndPassword, CompareHashAndPassword([]byte(tc.hash), []byte("x"+tc.password)))
+
+			salt, err := Base64Decode([]byte(tc.hash[6:28]))
+			assert.NoError(t, err)
+
+			hash, err := GenerateFromPasswordSaltMinor([]byte(tc.password), salt, 5, MinorVersionNone)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.hash, string(hash))
+
+			d, err := Parse(hash)
+			assert.NoError(t, err)
+			assert.Equal(t, MinorVersionNone, d.Minor)
+		})
+	}
+
+	// The hashes only differ from $2b$ for passwords shorter than 72 bytes.
+	hash := []byte("$2b$05$CCCCCCCCCCCCCCCCCCCCC.n2VnrmAaokJwiDSekcCjbZxRIyVngRy")
+	assert.NoError(t, CompareHashAndPassword(hash, []byte(testCases[2].password)))
+
+	hash = []byte("$2b$05$CCCCCCCCCCCCCCCCCCCCC.s9E2NDMJ4Db1NbCC8JPhLL29bHiDQtK")
+	assert.Equal(t, ErrMismatchedHashAndPassword, CompareHashAndPassword(hash, []byte("U*U")))
+
+	// Without the trailing NULL the key of "U*U" repeats the password, which is the key of $2b$ for the password
+	// repeated to 72 bytes.
+	assert.NoError(t, CompareHashAndPassword(hash, bytes.Repeat([]byte("U*U"), 24)))
+}
+
+func TestMinorVersionNoneKey(t *testing.T) {
+	password := bytes.Repeat([]byte("0123456789"), 30)
+
+	testCases := []struct {
+		name     string
+		password []byte
+		key      []byte
+	}{
+		{"Empty", nil, []byte{0}},
+		{"Short", []byte("U*U"), []byte("U*U")},
+		{"NULL", []byte("U*U\x00U*U"), []byte("U*U")},
+		{"LeadingNULL", []byte("\x00U*U"), []byte{0}},
+		{"Wraparound", password, password[:300-256]},
+		{"WraparoundZero", password[:256], password[:1]},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.key, expandPassword(tc.password, MinorVersionNone))
+		})
+	}
+
+	salt := []byte("XajjQvNhvvRt5GSeFk1xFe")
+
+	key, err := bcryptKey(context.Background(), password[:44], 5, salt)
+	assert.NoError(t, err)
+
+	hash := []byte("$2$05$" + string(salt) + string(key))
+	assert.NoError(t, CompareHashAndPassword(hash, password))
+	assert.NoError(t, CompareHashAndPassword(hash, append(password[:44:44], 0, 'x')))
+	assert.Equal(t, ErrMismatchedHashAndPassword, CompareHashAndPassword(hash, password[:45]))
+}
+
 // See Issue https://github.com/golang/go/issues/20425.
 func TestNoSideEffectsFromCompare(t *testing.T) {
 	source := []byte("passw0rd123456")
@@ -1,19 +1,61 @@
 package bcrypt
 
 const (
-	MinCost         int = 4  // the minimum allowable cost as passed in to GenerateFromPassword
-	MaxCost         int = 31 // the maximum allowable cost as passed in to GenerateFromPassword
-	DefaultCost     int = 10 // the cost that will actually be set if a cost below MinCost is passed into GenerateFromPassword
-	EncodedSaltSize     = 22
-	EncodedHashSize     = 31
+	MinCost           int = 4  // the minimum allowable cost as passed in to GenerateFromPassword
+	MaxCost           int = 31 // the maximum allowable cost as passed in to GenerateFromPassword
+	DefaultCost       int = 10 // the cost that will actually be set if a cost below MinCost is passed into GenerateFromPassword
+	MaxPasswordLength int = 72 // the maximum length of a password in bytes, longer passwords are truncated
+	EncodedSaltSize       = 22
+	EncodedHashSize       = 31
+)
+
+// The minor versions of bcrypt, which select the prefix of the hash and how the password is expanded into the key. A
+// password is at most 72 bytes long in all of them and longer passwords are truncated.
+const (
+	// MinorVersionNone is the $2$ version without a minor version, which the original OpenBSD implementation
+	// generated. The key is the password up to its first NULL byte without the trailing NULL, and the length of the
+	// key wrapped around at 256 bytes so only its first len%256 bytes are used, or its first byte if that is zero. It
+	// should only be used to verify legacy hashes.
+	MinorVersionNone byte = 0
+
+	// MinorVersionA is the $2a$ version. It expands passwords in the same manner as MinorVersionB, however when
+	// verifying a password of 255 bytes or more the OpenBSD expansion is also accepted, where the length of the
+	// password including the trailing NULL wrapped around at 256 bytes so only its first (len+1)%256 bytes are used,
+	// unless those bytes contain a NULL which a C string can not.
+	// Like crypt_blowfish, the key schedule is altered for passwords containing bytes with the high bit set which are
+	// expanded the same as by MinorVersionX, so their hashes differ. This requires 0xFF bytes and therefore can not
+	// occur in UTF-8. When verifying, the hashes of such passwords generated without this countermeasure, as OpenBSD
+	// does, are also accepted. It is the minor version used by GenerateFromPassword.
+	MinorVersionA byte = 'a'
+
+	// MinorVersionB is the $2b$ version which was introduced by OpenBSD to fix the wraparound of MinorVersionA.
+	MinorVersionB byte = 'b'
+
+	// MinorVersionX is the $2x$ version which crypt_blowfish uses for hashes generated before 1.1, where bytes with
+	// the high bit set were sign extended. It should only be used to verify legacy hashes.
+	MinorVersionX byte = 'x'
+
+	// MinorVersionY is the $2y$ version which crypt_blowfish uses for hashes generated after the sign extension bug
+	// was fixed. It expands passwords in the same manner as MinorVersionB.
+	MinorVersionY byte = 'y'
 )
 
 const (
 	majorVersion       = '2'
-	minorVersion       = 'a'
+	minorVersion       = MinorVersionA
 	maxSaltSize        = 16
 	maxCryptedHashSize = 23
 	minHashSize        = 59
+
+	// cancelRounds is the number of rounds of the key expansion between checks of the context, which is a few
+	// milliseconds.
+	cancelRounds = 64
+
+	// preHashPrefix is the prefix of the hashes of passwords pre-hashed by a Hasher, which precedes the bcrypt hash.
+	preHashPrefix = "$bcrypt-sha384"
+
+	// sha256Prefix is the prefix of the hashes in the format of the bcrypt_sha256 algorithm of passlib.
+	sha256Prefix = "$bcrypt-sha256$"
 )
 
 // magicCipherData is an IV for the 64 Blowfish encryption calls in
@@ -0,0 +1,117 @@
+package bcrypt
+
+// Digest is a parsed bcrypt hash in the modular crypt format:
+//
+//	$2[<minor>]$<cost>$<salt><checksum>
+//
+// Where <salt> and <checksum> are encoded with the bcrypt alphabet of base64 without padding, and the hash is preceded
+// by $bcrypt-sha384 if the password was pre-hashed as per Hasher.PreHash.
+type Digest struct {
+	// Major is the major version, which is '2' for all hashes generated by this package. Marshal treats zero as '2'.
+	Major byte
+
+	// Minor is the minor version, i.e. MinorVersionA, MinorVersionB, MinorVersionX, or MinorVersionY, or zero if the
+	// hash has no minor version.
+	Minor byte
+
+	// Cost is the cost of the hash.
+	Cost int
+
+	// Salt is the decoded salt, which is 16 bytes long.
+	Salt []byte
+
+	// Checksum is the decoded checksum, which is the first 23 bytes of the encrypted magic cipher data.
+	Checksum []byte
+
+	// PreHash is true if the password was pre-hashed with HMAC-SHA-384 before it was hashed with bcrypt.
+	PreHash bool
+}
+
+// Parse parses a bcrypt hash into a Digest. It returns an error if the hash is malformed rather than panicking.
+func Parse(hashedPassword []byte) (d *Digest, err error) {
+	p, err := newFromHash(hashedPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	d = &Digest{
+		Major:   p.major,
+		Minor:   p.minor,
+		Cost:    p.cost,
+		PreHash: p.prehash,
+	}
+
+	if d.Salt, err = Base64Decode(p.salt); err != nil {
+		return nil, err
+	}
+
+	if d.Checksum, err = Base64Decode(p.hash); err != nil {
+		return nil, err
+	}
+
+	if err = d.check(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Marshal returns the bcrypt hash of the Digest in the modular crypt format, or an error if any of its fields are
+// invalid. It is the inverse of Parse.
+func (d *Digest) Marshal() ([]byte, error) {
+	if err := d.check(); err != nil {
+		return nil, err
+	}
+
+	p := &hashed{
+		hash:    Base64Encode(d.Checksum),
+		salt:    Base64Encode(d.Salt),
+		cost:    d.Cost,
+		major:   majorVersion,
+		minor:   d.Minor,
+		prehash: d.PreHash,
+	}
+
+	if d.Major != 0 {
+		p.major = d.Major
+	}
+
+	return p.Hash(), nil
+}
+
+// NeedsRehash returns true if the Digest does not have the target cost and minor version, which means the password
+// should be hashed again the next time it is available. As with GenerateFromPassword a target cost below MinCost is
+// treated as DefaultCost. Unlike Hasher.Minor a zero target minor version is MinorVersionNone, not MinorVersionA.
+func (d *Digest) NeedsRehash(targetCost int, targetMinor byte) bool {
+	if targetCost < MinCost {
+		targetCost = DefaultCost
+	}
+
+	return d.Cost != targetCost || d.Minor != targetMinor
+}
+
+func (d *Digest) check() error {
+	if d.Major > majorVersion {
+		return HashVersionTooNewError(d.Major)
+	}
+
+	if d.Minor != 0 {
+		if err := checkMinor(d.Minor); err != nil {
+			return err
+		}
+	}
+
+	if err := checkCost(d.Cost); err != nil {
+		return err
+	}
+
+	if err := checkSalt(d.Salt); err != nil {
+		return err
+	}
+
+	if len(d.Checksum) != maxCryptedHashSize {
+		return ErrSecretInvalidLength
+	}
+
+	return nil
+}
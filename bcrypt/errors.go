@@ -16,8 +16,68 @@ var (
 
 	// ErrSecretInvalidLength is the error returned when a hash secret is too short to be a bcrypt secret.
 	ErrSecretInvalidLength = errors.New("github.com/go-crypt/x/bcrypt: secret has an invalid length for a bcrypt secret")
+
+	// ErrMissingSeparator is the error wrapped by a ParseError when a hash does not have a '$' after the version or
+	// the cost.
+	ErrMissingSeparator = errors.New("github.com/go-crypt/x/bcrypt: hashed secret is missing a '$' separator")
+
+	// ErrInvalidCharacter is the error wrapped by a ParseError when the cost of a hash is not decimal, or the salt or
+	// checksum of a hash is not in the bcrypt base64 alphabet.
+	ErrInvalidCharacter = errors.New("github.com/go-crypt/x/bcrypt: hashed secret contains an invalid character")
+
+	// ErrPasswordTooLong is the error returned by a strict Hasher when a password is longer than MaxPasswordLength
+	// and would therefore be truncated.
+	ErrPasswordTooLong = errors.New("github.com/go-crypt/x/bcrypt: password length exceeds 72 bytes")
+
+	// ErrInvalidSHA256Hash is the error returned from CompareHashAndPasswordSHA256 when a hash is not in the format
+	// of the bcrypt_sha256 algorithm of passlib, version 2.
+	ErrInvalidSHA256Hash = errors.New("github.com/go-crypt/x/bcrypt: hashed secret is not a passlib bcrypt-sha256 version 2 hash")
+
+	// ErrPBKDFRounds is the error returned from PBKDF when the number of rounds is less than 1.
+	ErrPBKDFRounds = errors.New("github.com/go-crypt/x/bcrypt: pbkdf number of rounds is too small")
+
+	// ErrPBKDFPassword is the error returned from PBKDF when the password is empty.
+	ErrPBKDFPassword = errors.New("github.com/go-crypt/x/bcrypt: pbkdf password is empty")
+
+	// ErrPBKDFSaltLength is the error returned from PBKDF when the salt is empty or longer than 1 MiB.
+	ErrPBKDFSaltLength = errors.New("github.com/go-crypt/x/bcrypt: pbkdf salt length is outside allowed inclusive range 1..1048576")
+
+	// ErrPBKDFKeyLength is the error returned from PBKDF when the key length is less than 1 or more than 1024 bytes.
+	ErrPBKDFKeyLength = errors.New("github.com/go-crypt/x/bcrypt: pbkdf key length is outside allowed inclusive range 1..1024")
 )
 
+// The fields of a hash reported by a ParseError.
+const (
+	FieldHash     = "hash"
+	FieldPrefix   = "prefix"
+	FieldMajor    = "major"
+	FieldMinor    = "minor"
+	FieldCost     = "cost"
+	FieldSalt     = "salt"
+	FieldChecksum = "checksum"
+)
+
+// ParseError is the error returned when a hash can not be parsed. It wraps the cause, which is one of the sentinel or
+// typed errors of this package, so errors.Is and errors.As can be used to test for it.
+type ParseError struct {
+	// Field is the field of the hash which is malformed, i.e. one of the Field constants.
+	Field string
+
+	// Offset is the offset in bytes of the malformed byte in the hash, or of the end of the hash if it is too short.
+	Offset int
+
+	// Err is the cause of the error.
+	Err error
+}
+
+func (pe *ParseError) Error() string {
+	return fmt.Sprintf("%v (%s at offset %d)", pe.Err, pe.Field, pe.Offset)
+}
+
+func (pe *ParseError) Unwrap() error {
+	return pe.Err
+}
+
 // The error returned from CompareHashAndPassword when a hash was created with
 // a bcrypt algorithm newer than this implementation.
 type HashVersionTooNewError byte
@@ -33,6 +93,14 @@ func (ih InvalidHashPrefixError) Error() string {
 	return fmt.Sprintf("github.com/go-crypt/x/bcrypt: bcrypt hashes must start with '$', but hashedSecret started with '%c'", byte(ih))
 }
 
+// The error returned when a hash or GenerateFromPasswordMinor has a minor version other than MinorVersionA,
+// MinorVersionB, MinorVersionX, or MinorVersionY.
+type InvalidMinorVersionError byte
+
+func (im InvalidMinorVersionError) Error() string {
+	return fmt.Sprintf("github.com/go-crypt/x/bcrypt: bcrypt minor version '%c' is not one of 'a', 'b', 'x', or 'y'", byte(im))
+}
+
 type InvalidCostError int
 
 func (ic InvalidCostError) Error() string {
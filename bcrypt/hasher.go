@@ -0,0 +1,73 @@
+package bcrypt
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+)
+
+// Hasher generates and compares bcrypt hashes with options which are not available to GenerateFromPassword and
+// CompareHashAndPassword. The zero value of Hasher behaves like those functions.
+type Hasher struct {
+	// Cost is the cost of the generated hashes. If it is less than MinCost DefaultCost is used instead.
+	Cost int
+
+	// Minor is the minor version of the generated hashes as per GenerateFromPasswordMinor. If it is zero
+	// MinorVersionA is used, so unlike with GenerateFromPasswordMinor a Hasher can not generate $2$ hashes of
+	// MinorVersionNone, which should only be used to verify legacy hashes.
+	Minor byte
+
+	// Strict returns ErrPasswordTooLong for passwords longer than MaxPasswordLength instead of truncating them, both
+	// when generating and when comparing hashes which are not pre-hashed.
+	Strict bool
+
+	// PreHash pre-hashes the password with HMAC-SHA-384 keyed by the encoded salt and encodes the result with standard
+	// base64 before it is hashed with bcrypt, so that the whole of a password of any length is used. The resulting
+	// hash is the bcrypt hash preceded by $bcrypt-sha384, for example:
+	//
+	//	$bcrypt-sha384$2a$10$<salt><hash>
+	//
+	// CompareHashAndPassword recognises these hashes regardless of this option.
+	PreHash bool
+}
+
+// Generate returns the bcrypt hash of the password. Use Compare or CompareHashAndPassword, as defined in this package,
+// to compare the returned hashed password with its cleartext version.
+func (h Hasher) Generate(password []byte) ([]byte, error) {
+	if h.Strict && !h.PreHash && len(password) > MaxPasswordLength {
+		return nil, ErrPasswordTooLong
+	}
+
+	salt, err := NewSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	minor := h.Minor
+	if minor == 0 {
+		minor = minorVersion
+	}
+
+	p, err := newFromPasswordSalt(context.Background(), password, salt, h.Cost, minor, h.PreHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Hash(), nil
+}
+
+// Compare compares a bcrypt hashed password with its possible plaintext equivalent in the same manner as
+// CompareHashAndPassword, except that a strict Hasher returns ErrPasswordTooLong if the password would be truncated.
+func (h Hasher) Compare(hashedPassword, password []byte) error {
+	return compareHashAndPassword(context.Background(), hashedPassword, password, h.Strict)
+}
+
+// preHashPassword returns the HMAC-SHA-384 of the password keyed by the encoded salt, encoded with standard base64.
+// At 64 bytes it is never truncated.
+func preHashPassword(password, salt []byte) []byte {
+	mac := hmac.New(sha512.New384, salt)
+	mac.Write(password)
+
+	return base64.StdEncoding.AppendEncode(nil, mac.Sum(nil))
+}
@@ -0,0 +1,100 @@
+package bcrypt
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+
+	"github.com/go-crypt/x/blowfish"
+)
+
+const (
+	pbkdfBlockSize = 32
+	pbkdfMaxKeyLen = pbkdfBlockSize * pbkdfBlockSize
+	pbkdfMaxSalt   = 1 << 20
+)
+
+// pbkdfMagic is the data encrypted by each iteration of PBKDF, in place of the magic cipher data of bcrypt.
+var pbkdfMagic = []byte("OxychromaticBlowfishSwatDynamite")
+
+// PBKDF derives a key of keyLen bytes from the password and salt with the given number of rounds using bcrypt_pbkdf
+// as implemented by OpenBSD, which is used by OpenSSH to encrypt the private keys in the openssh-key-v1 format. It is
+// PBKDF2 with the pseudorandom function replaced by a variant of bcrypt with a fixed cost of 6, which is keyed by the
+// SHA-512 of the password and salted with the SHA-512 of the salt and block counter. The output of each block is
+// interleaved across the key, so keyLen can be anything from 1 to 1024 bytes.
+func PBKDF(password, salt []byte, rounds, keyLen int) ([]byte, error) {
+	switch {
+	case rounds < 1:
+		return nil, ErrPBKDFRounds
+	case len(password) == 0:
+		return nil, ErrPBKDFPassword
+	case len(salt) == 0 || len(salt) > pbkdfMaxSalt:
+		return nil, ErrPBKDFSaltLength
+	case keyLen < 1 || keyLen > pbkdfMaxKeyLen:
+		return nil, ErrPBKDFKeyLength
+	}
+
+	blocks := (keyLen + pbkdfBlockSize - 1) / pbkdfBlockSize
+	key := make([]byte, blocks*pbkdfBlockSize)
+
+	h := sha512.New()
+	h.Write(password)
+	shapass := h.Sum(nil)
+
+	var (
+		c       blowfish.Cipher
+		shasalt = make([]byte, 0, sha512.Size)
+		cnt     [4]byte
+		tmp     [pbkdfBlockSize]byte
+		out     [pbkdfBlockSize]byte
+	)
+
+	for block := 1; block <= blocks; block++ {
+		binary.BigEndian.PutUint32(cnt[:], uint32(block))
+
+		h.Reset()
+		h.Write(salt)
+		h.Write(cnt[:])
+		pbkdfHash(&c, tmp[:], shapass, h.Sum(shasalt))
+
+		out = tmp
+		for i := 2; i <= rounds; i++ {
+			h.Reset()
+			h.Write(tmp[:])
+			pbkdfHash(&c, tmp[:], shapass, h.Sum(shasalt))
+
+			for j := range out {
+				out[j] ^= tmp[j]
+			}
+		}
+
+		for i, v := range out {
+			key[i*blocks+block-1] = v
+		}
+	}
+
+	return key[:keyLen], nil
+}
+
+// pbkdfHash is the bcrypt variant used as the pseudorandom function of PBKDF. Unlike bcrypt the salt is expanded
+// before the key in every round, and all 32 bytes of the encrypted data are output as little-endian words. The key
+// schedule is computed in c, which is reused between calls.
+func pbkdfHash(c *blowfish.Cipher, out, shapass, shasalt []byte) {
+	c.Init()
+	blowfish.ExpandKeyWithSalt(shapass, shasalt, c)
+
+	for i := 0; i < 64; i++ {
+		blowfish.ExpandKey(shasalt, c)
+		blowfish.ExpandKey(shapass, c)
+	}
+
+	copy(out, pbkdfMagic)
+	for i := 0; i < pbkdfBlockSize; i += 8 {
+		for j := 0; j < 64; j++ {
+			c.Encrypt(out[i:i+8], out[i:i+8])
+		}
+	}
+
+	for i := 0; i < pbkdfBlockSize; i += 4 {
+		binary.LittleEndian.PutUint32(out[i:], binary.BigEndian.Uint32(out[i:]))
+	}
+}
@@ -0,0 +1,116 @@
+package bcrypt
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+)
+
+// GenerateFromPasswordSHA256 returns the hash of the password at the given cost in the format of the bcrypt_sha256
+// algorithm of passlib, version 2:
+//
+//	$bcrypt-sha256$v=2,t=2b,r=<cost>$<salt>$<checksum>
+//
+// The password is replaced by the HMAC-SHA256 of the password keyed by the encoded salt, encoded with standard base64,
+// before it is hashed with bcrypt, so that the whole of a password of any length is used. If the cost given is less
+// than MinCost, the cost will be set to DefaultCost, instead. Use CompareHashAndPasswordSHA256, as defined in this
+// package, to compare the returned hashed password with its cleartext version.
+func GenerateFromPasswordSHA256(password []byte, cost int) ([]byte, error) {
+	salt, err := NewSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	key := sha256Password(password, Base64Encode(salt))
+
+	p, err := newFromPasswordSalt(context.Background(), key, salt, cost, MinorVersionB, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return fmt.Appendf(nil, "%sv=2,t=%c%c,r=%d$%s$%s", sha256Prefix, p.major, p.minor, p.cost, p.salt, p.hash), nil
+}
+
+// CompareHashAndPasswordSHA256 compares a hashed password in the format of the bcrypt_sha256 algorithm of passlib,
+// version 2, with its possible plaintext equivalent. As with passlib only the 2a and 2b minor versions of bcrypt are
+// accepted. Returns nil on success, or an error on failure.
+func CompareHashAndPasswordSHA256(hashedPassword, password []byte) error {
+	p, err := newFromSHA256Hash(hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	return compareHashAndPassword(context.Background(), p.Hash(), sha256Password(password, p.salt), false)
+}
+
+func newFromSHA256Hash(hashedPassword []byte) (p *hashed, err error) {
+	rest, ok := bytes.CutPrefix(hashedPassword, []byte(sha256Prefix))
+	if !ok {
+		return nil, ErrInvalidSHA256Hash
+	}
+
+	params, rest, ok := bytes.Cut(rest, []byte{'$'})
+	if !ok || len(rest) != EncodedSaltSize+1+EncodedHashSize || rest[EncodedSaltSize] != '$' {
+		return nil, ErrInvalidSHA256Hash
+	}
+
+	fields := bytes.Split(params, []byte{','})
+	if len(fields) != 3 || string(fields[0]) != "v=2" {
+		return nil, ErrInvalidSHA256Hash
+	}
+
+	p = &hashed{
+		salt: rest[:EncodedSaltSize],
+		hash: rest[EncodedSaltSize+1:],
+	}
+
+	version, ok := bytes.CutPrefix(fields[1], []byte("t="))
+	if !ok || len(version) != 2 || version[0] != majorVersion {
+		return nil, ErrInvalidSHA256Hash
+	}
+
+	if err = checkMinor(version[1]); err != nil {
+		return nil, err
+	}
+
+	if version[1] != MinorVersionA && version[1] != MinorVersionB {
+		return nil, ErrInvalidSHA256Hash
+	}
+
+	p.major, p.minor = version[0], version[1]
+
+	// The cost has one or two digits without a leading zero.
+	rounds, ok := bytes.CutPrefix(fields[2], []byte("r="))
+	if !ok || len(rounds) == 0 || len(rounds) > 2 || rounds[0] == '0' {
+		return nil, ErrInvalidSHA256Hash
+	}
+
+	for _, c := range rounds {
+		if c < '0' || c > '9' {
+			return nil, ErrInvalidSHA256Hash
+		}
+	}
+
+	if p.cost, err = strconv.Atoi(string(rounds)); err != nil {
+		return nil, ErrInvalidSHA256Hash
+	}
+
+	if err = checkCost(p.cost); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// sha256Password returns the HMAC-SHA256 of the password keyed by the encoded salt, encoded with standard base64.
+// At 44 bytes it is never truncated.
+func sha256Password(password, salt []byte) []byte {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(password)
+
+	return base64.StdEncoding.AppendEncode(nil, mac.Sum(nil))
+}
@@ -64,11 +64,12 @@ func ExpandKey(key []byte, c *Cipher) {
 	}
 }
 
-// This is similar to ExpandKey, but folds the salt during the key
-// schedule. While ExpandKey is essentially expandKeyWithSalt with an all-zero
-// salt passed in, reusing ExpandKey turns out to be a place of inefficiency
-// and specializing it here is useful.
-func expandKeyWithSalt(key []byte, salt []byte, c *Cipher) {
+// ExpandKeyWithSalt is similar to ExpandKey, but folds the salt during the
+// key schedule. While ExpandKey is essentially ExpandKeyWithSalt with an
+// all-zero salt passed in, reusing ExpandKey turns out to be a place of
+// inefficiency and specializing it here is useful. The key and salt must not
+// be empty.
+func ExpandKeyWithSalt(key []byte, salt []byte, c *Cipher) {
 	j := 0
 	for i := 0; i < 18; i++ {
 		c.p[i] ^= getNextWord(key, &j)
@@ -4,7 +4,15 @@
 
 package blowfish
 
-import "testing"
+import (
+	"bytes"
+	"crypto/cipher"
+	stdbase64 "encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/go-crypt/x/base64"
+)
 
 type CryptTest struct {
 	key []byte
@@ -256,12 +264,233 @@ func TestSaltedCipher(t *testing.T) {
 	}
 }
 
+// The modes of operation test vectors are from https://www.schneier.com/code/vectors.txt, which are also used by
+// bftest.c in OpenSSL. The data is "7654321 Now is the time for " followed by a NUL, padded with zeros for CBC.
+var (
+	modeKey  = "0123456789abcdeff0e1d2c3b4a59687"
+	modeIV   = "fedcba9876543210"
+	modeData = "37363534333231204e6f77206973207468652074696d6520666f722000"
+)
+
+func TestModes(t *testing.T) {
+	key, _ := hex.DecodeString(modeKey)
+	iv, _ := hex.DecodeString(modeIV)
+	data, _ := hex.DecodeString(modeData)
+
+	testCases := []struct {
+		name      string
+		plaintext []byte
+		encrypter func(b cipher.Block) func(dst, src []byte)
+		decrypter func(b cipher.Block) func(dst, src []byte)
+		expected  string
+	}{
+		{
+			"CBC",
+			append(data[:len(data):len(data)], 0, 0, 0),
+			func(b cipher.Block) func(dst, src []byte) { return cipher.NewCBCEncrypter(b, iv).CryptBlocks },
+			func(b cipher.Block) func(dst, src []byte) { return cipher.NewCBCDecrypter(b, iv).CryptBlocks },
+			"6b77b4d63006dee605b156e27403979358deb9e7154616d959f1652bd5ff92cc",
+		},
+		{
+			"CFB",
+			data,
+			func(b cipher.Block) func(dst, src []byte) { return cipher.NewCFBEncrypter(b, iv).XORKeyStream },
+			func(b cipher.Block) func(dst, src []byte) { return cipher.NewCFBDecrypter(b, iv).XORKeyStream },
+			"e73214a2822139caf26ecf6d2eb9e76e3da3de04d1517200519d57a6c3",
+		},
+		{
+			"OFB",
+			data,
+			func(b cipher.Block) func(dst, src []byte) { return cipher.NewOFB(b, iv).XORKeyStream },
+			func(b cipher.Block) func(dst, src []byte) { return cipher.NewOFB(b, iv).XORKeyStream },
+			"e73214a2822139ca62b343cc5b65587310dd908d0c241b2263c2cf80da",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewCipher(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ct := make([]byte, len(tc.plaintext))
+			tc.encrypter(c)(ct, tc.plaintext)
+
+			if actual := hex.EncodeToString(ct); actual != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, actual)
+			}
+
+			pt := make([]byte, len(ct))
+			tc.decrypter(c)(pt, ct)
+
+			if !bytes.Equal(pt, tc.plaintext) {
+				t.Errorf("expected %x, got %x", tc.plaintext, pt)
+			}
+		})
+	}
+}
+
+// CTR is not covered by an external test vector as neither test suite has one. This only checks that cipher.NewCTR
+// over a Cipher is consistent with encrypting the counter blocks using Cipher.Encrypt, which the vectors above cover.
+func TestModeCTRConsistency(t *testing.T) {
+	key, _ := hex.DecodeString(modeKey)
+	iv, _ := hex.DecodeString(modeIV)
+	data, _ := hex.DecodeString(modeData)
+
+	c, err := NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ct := make([]byte, len(data))
+	cipher.NewCTR(c, iv).XORKeyStream(ct, data)
+
+	counter := make([]byte, BlockSize)
+	copy(counter, iv)
+
+	expected := make([]byte, len(data))
+	for i := 0; i < len(data); i += BlockSize {
+		var stream [BlockSize]byte
+		c.Encrypt(stream[:], counter)
+
+		for j := i; j < min(i+BlockSize, len(data)); j++ {
+			expected[j] = data[j] ^ stream[j-i]
+		}
+
+		// The counter is big-endian and the IV ends in 0x10, so the increment never carries.
+		counter[BlockSize-1]++
+	}
+
+	if !bytes.Equal(ct, expected) {
+		t.Errorf("expected %x, got %x", expected, ct)
+	}
+
+	pt := make([]byte, len(ct))
+	cipher.NewCTR(c, iv).XORKeyStream(pt, ct)
+
+	if !bytes.Equal(pt, data) {
+		t.Errorf("expected %x, got %x", data, pt)
+	}
+}
+
+func TestCipherReset(t *testing.T) {
+	c, err := NewCipher([]byte("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Reset()
+
+	if *c != (Cipher{}) {
+		t.Errorf("expected the key schedule to be zeroed")
+	}
+}
+
+func TestCipherCopy(t *testing.T) {
+	c, err := NewCipher([]byte("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone := c.Clone()
+	if clone == c || *clone != *c {
+		t.Fatalf("expected the clone to be an equal copy")
+	}
+
+	ExpandKey([]byte("other"), clone)
+	if *clone == *c {
+		t.Fatalf("expected the clone not to share the key schedule")
+	}
+
+	var copied Cipher
+	copied.CopyFrom(c)
+
+	if copied != *c {
+		t.Errorf("expected the copy to be equal")
+	}
+}
+
+func TestCipherInit(t *testing.T) {
+	var key, salt [32]byte
+	for i := range key {
+		key[i] = byte(i)
+		salt[i] = byte(i + 32)
+	}
+
+	c, err := NewCipher([]byte("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, v := range saltedVectors[1:] {
+		c.Init()
+		ExpandKeyWithSalt(key[:], salt[:i+1], c)
+
+		var buf [8]byte
+		c.Encrypt(buf[:], buf[:])
+		if v != buf {
+			t.Errorf("%d: expected %x, got %x", i+1, v, buf)
+		}
+	}
+
+	if n := testing.AllocsPerRun(10, func() { c.Init(); ExpandKeyWithSalt(key[:], salt[:], c) }); n != 0 {
+		t.Errorf("expected no allocations, got %v", n)
+	}
+}
+
+func TestEksblowfishCipher(t *testing.T) {
+	// The bcrypt hash of the empty password "$2a$05$CCCCCCCCCCCCCCCCCCCCC.7uG0VCzI2bS7j6ymqJi9CdcdxiRTWNy" from the
+	// crypt_blowfish test vectors, where the key is the password with the trailing NULL.
+	salt, err := base64.BcryptEncoding.WithPadding(stdbase64.NoPadding).DecodeString("CCCCCCCCCCCCCCCCCCCCC.")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := base64.BcryptEncoding.WithPadding(stdbase64.NoPadding).DecodeString("7uG0VCzI2bS7j6ymqJi9CdcdxiRTWNy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewEksblowfishCipher(5, salt, []byte{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("OrpheanBeholderScryDoubt")
+	for i := 0; i < len(data); i += BlockSize {
+		for j := 0; j < 64; j++ {
+			c.Encrypt(data[i:i+BlockSize], data[i:i+BlockSize])
+		}
+	}
+
+	if !bytes.Equal(data[:len(expected)], expected) {
+		t.Errorf("expected %x, got %x", expected, data[:len(expected)])
+	}
+}
+
+func TestEksblowfishCipherErrors(t *testing.T) {
+	salt := make([]byte, 16)
+
+	if _, err := NewEksblowfishCipher(32, salt, []byte("key")); err != CostError(32) {
+		t.Errorf("NewEksblowfishCipher with cost 32, gave error %#v, expected %#v", err, CostError(32))
+	}
+
+	if _, err := NewEksblowfishCipher(4, nil, []byte("key")); err != SaltSizeError(0) {
+		t.Errorf("NewEksblowfishCipher with empty salt, gave error %#v, expected %#v", err, SaltSizeError(0))
+	}
+
+	if _, err := NewEksblowfishCipher(4, salt, nil); err != KeySizeError(0) {
+		t.Errorf("NewEksblowfishCipher with empty key, gave error %#v, expected %#v", err, KeySizeError(0))
+	}
+}
+
 func BenchmarkExpandKeyWithSalt(b *testing.B) {
 	key := make([]byte, 32)
 	salt := make([]byte, 16)
 	c, _ := NewCipher(key)
 	for i := 0; i < b.N; i++ {
-		expandKeyWithSalt(key, salt, c)
+		ExpandKeyWithSalt(key, salt, c)
 	}
 }
 
@@ -15,7 +15,10 @@ package blowfish
 // The code is a port of Bruce Schneier's C implementation.
 // See https://www.schneier.com/blowfish.html.
 
-import "strconv"
+import (
+	"crypto/cipher"
+	"strconv"
+)
 
 // A Cipher is an instance of Blowfish encryption using a particular key.
 type Cipher struct {
@@ -23,12 +26,26 @@ type Cipher struct {
 	s0, s1, s2, s3 [256]uint32
 }
 
+var _ cipher.Block = (*Cipher)(nil)
+
 type KeySizeError int
 
 func (k KeySizeError) Error() string {
 	return "crypto/blowfish: invalid key size " + strconv.Itoa(int(k))
 }
 
+type SaltSizeError int
+
+func (s SaltSizeError) Error() string {
+	return "crypto/blowfish: invalid salt size " + strconv.Itoa(int(s))
+}
+
+type CostError uint
+
+func (c CostError) Error() string {
+	return "crypto/blowfish: invalid cost " + strconv.FormatUint(uint64(c), 10) + ", the maximum is " + strconv.Itoa(maxEksblowfishCost)
+}
+
 // NewCipher creates and returns a Cipher.
 // The key argument should be the Blowfish key, from 1 to 56 bytes.
 func NewCipher(key []byte) (*Cipher, error) {
@@ -54,7 +71,34 @@ func NewSaltedCipher(key, salt []byte) (*Cipher, error) {
 		return nil, KeySizeError(k)
 	}
 	initCipher(&result)
-	expandKeyWithSalt(key, salt, &result)
+	ExpandKeyWithSalt(key, salt, &result)
+	return &result, nil
+}
+
+// NewEksblowfishCipher creates and returns a Cipher with the expensive key
+// schedule of bcrypt, EksBlowfishSetup from "A Future-Adaptable Password
+// Scheme" by Provos and Mazières. The salt is folded into the key schedule as
+// per NewSaltedCipher, then the key and the salt are expanded into it
+// alternately 2^cost times. The cost must be at most 31, the salt must not be
+// empty, and the key is used as is, so for bcrypt compatibility it must
+// include the trailing NULL and only its first 72 bytes are used.
+func NewEksblowfishCipher(cost uint, salt, key []byte) (*Cipher, error) {
+	if cost > maxEksblowfishCost {
+		return nil, CostError(cost)
+	}
+	if len(salt) == 0 {
+		return nil, SaltSizeError(0)
+	}
+	if k := len(key); k < 1 {
+		return nil, KeySizeError(k)
+	}
+	var result Cipher
+	initCipher(&result)
+	ExpandKeyWithSalt(key, salt, &result)
+	for i := uint64(0); i < 1<<cost; i++ {
+		ExpandKey(key, &result)
+		ExpandKey(salt, &result)
+	}
 	return &result, nil
 }
 
@@ -86,6 +130,38 @@ func (c *Cipher) Decrypt(dst, src []byte) {
 	dst[4], dst[5], dst[6], dst[7] = byte(r>>24), byte(r>>16), byte(r>>8), byte(r)
 }
 
+// Reset zeroes the key schedule of the Cipher, so that the key can not be
+// recovered from the memory it occupied. The Cipher must not be used after
+// it is reset.
+func (c *Cipher) Reset() {
+	clear(c.p[:])
+	clear(c.s0[:])
+	clear(c.s1[:])
+	clear(c.s2[:])
+	clear(c.s3[:])
+}
+
+// Clone returns a copy of the Cipher, which has the same key schedule.
+func (c *Cipher) Clone() *Cipher {
+	clone := *c
+	return &clone
+}
+
+// CopyFrom sets the key schedule of the Cipher to that of src without
+// allocating, for example to reuse a Cipher which was keyed once for many
+// expensive key schedules.
+func (c *Cipher) CopyFrom(src *Cipher) {
+	*c = *src
+}
+
+// Init sets the key schedule of the Cipher to the initial state of Blowfish,
+// which is derived from the digits of pi, before any key is expanded into it.
+// Together with ExpandKey or ExpandKeyWithSalt it allows a Cipher to be keyed
+// again without allocating.
+func (c *Cipher) Init() {
+	initCipher(c)
+}
+
 func initCipher(c *Cipher) {
 	copy(c.p[0:], p[0:])
 	copy(c.s0[0:], s0[0:])
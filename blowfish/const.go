@@ -11,6 +11,10 @@ package blowfish
 // BlockSize is the Blowfish block size in bytes.
 const BlockSize = 8
 
+// maxEksblowfishCost is the maximum cost of NewEksblowfishCipher, which is the
+// maximum cost of bcrypt.
+const maxEksblowfishCost = 31
+
 var s0 = [256]uint32{
 	0xd1310ba6, 0x98dfb5ac, 0x2ffd72db, 0xd01adfb7, 0xb8e1afed, 0x6a267e96,
 	0xba7c9045, 0xf12c7f99, 0x24a19947, 0xb3916cf7, 0x0801f2e2, 0x858efc16,
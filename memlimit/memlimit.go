@@ -0,0 +1,227 @@
+// Package memlimit implements a process-wide limit on the memory in use by the memory-hard key derivation functions
+// in this module, which are argon2, scrypt, and yescrypt. Each of them allocates the full memory cost for every
+// derivation, so without a limit a burst of concurrent derivations can exhaust the available memory.
+//
+// By default there is no limit. Use SetDefault with a Limiter to set one:
+//
+//	memlimit.SetDefault(memlimit.NewLimiter(512<<20, true))
+//
+// The limit only covers the memory in use by derivations which are running. Memory which has been released may still
+// be held by the process until it is garbage collected.
+package memlimit
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrExceedsLimit is the error returned when the memory requested is larger than the limit, and therefore can
+	// never be acquired.
+	ErrExceedsLimit = errors.New("github.com/go-crypt/x/memlimit: the memory requested exceeds the limit")
+
+	// ErrLimitReached is the error returned by a Limiter which does not wait when the memory requested would cause
+	// the memory in use to exceed the limit.
+	ErrLimitReached = errors.New("github.com/go-crypt/x/memlimit: the memory requested would exceed the limit of memory in use")
+)
+
+var defaultLimiter atomic.Pointer[Limiter]
+
+// SetDefault sets the Limiter consulted by the argon2, scrypt, and yescrypt packages before they allocate memory. A
+// nil Limiter removes the limit. The argon2 IDKey, IKey, and DKey functions and their WithSecret variants cannot
+// return an error, so they do not consult the Limiter.
+func SetDefault(l *Limiter) {
+	defaultLimiter.Store(l)
+}
+
+// Default returns the Limiter set with SetDefault, or nil if there is no limit.
+func Default() *Limiter {
+	return defaultLimiter.Load()
+}
+
+// Acquire acquires n bytes from the default Limiter using Limiter.Acquire, returning a function which releases them.
+// If there is no default Limiter it returns immediately.
+func Acquire(ctx context.Context, n uint64) (release func(), err error) {
+	return acquire(ctx, n, (*Limiter).Acquire)
+}
+
+// Wait acquires n bytes from the default Limiter using Limiter.Wait, returning a function which releases them. If
+// there is no default Limiter it returns immediately.
+func Wait(ctx context.Context, n uint64) (release func(), err error) {
+	return acquire(ctx, n, (*Limiter).Wait)
+}
+
+func acquire(ctx context.Context, n uint64, fn func(l *Limiter, ctx context.Context, n uint64) error) (release func(), err error) {
+	l := Default()
+	if l == nil {
+		return func() {}, nil
+	}
+
+	if err = fn(l, ctx, n); err != nil {
+		return nil, err
+	}
+
+	return func() { l.Release(n) }, nil
+}
+
+// Limiter limits the number of bytes of memory in use at once. Requests for memory are granted in the order they are
+// made. A Limiter is safe for concurrent use.
+type Limiter struct {
+	limit uint64
+	wait  bool
+
+	mu      sync.Mutex
+	inUse   uint64
+	waiters list.List
+}
+
+type waiter struct {
+	n     uint64
+	ready chan struct{}
+}
+
+// NewLimiter returns a Limiter which allows up to limit bytes of memory in use at once. If wait is true Acquire waits
+// for the memory to be released by other derivations, otherwise it returns ErrLimitReached.
+func NewLimiter(limit uint64, wait bool) *Limiter {
+	return &Limiter{limit: limit, wait: wait}
+}
+
+// Limit returns the maximum number of bytes of memory in use at once.
+func (l *Limiter) Limit() uint64 {
+	return l.limit
+}
+
+// InUse returns the number of bytes of memory currently acquired.
+func (l *Limiter) InUse() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.inUse
+}
+
+// Acquire acquires n bytes of memory, either waiting for it as per Wait or failing as per TryAcquire depending on how
+// the Limiter was created. It returns ErrExceedsLimit if n is larger than the limit.
+func (l *Limiter) Acquire(ctx context.Context, n uint64) error {
+	if l.wait {
+		return l.Wait(ctx, n)
+	}
+
+	if n > l.limit {
+		return ErrExceedsLimit
+	}
+
+	if !l.TryAcquire(n) {
+		return ErrLimitReached
+	}
+
+	return nil
+}
+
+// TryAcquire acquires n bytes of memory without waiting, returning false if it is not available.
+func (l *Limiter) TryAcquire(n uint64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limit-l.inUse >= n && l.waiters.Len() == 0 {
+		l.inUse += n
+
+		return true
+	}
+
+	return false
+}
+
+// Wait acquires n bytes of memory, waiting until it is available or ctx is done. On failure it returns ctx.Err(), or
+// ErrExceedsLimit if n is larger than the limit.
+func (l *Limiter) Wait(ctx context.Context, n uint64) error {
+	done := ctx.Done()
+
+	l.mu.Lock()
+
+	select {
+	case <-done:
+		l.mu.Unlock()
+
+		return ctx.Err()
+	default:
+	}
+
+	if l.limit-l.inUse >= n && l.waiters.Len() == 0 {
+		l.inUse += n
+		l.mu.Unlock()
+
+		return nil
+	}
+
+	if n > l.limit {
+		l.mu.Unlock()
+
+		return ErrExceedsLimit
+	}
+
+	ready := make(chan struct{})
+	elem := l.waiters.PushBack(waiter{n: n, ready: ready})
+
+	l.mu.Unlock()
+
+	select {
+	case <-done:
+		l.mu.Lock()
+
+		select {
+		case <-ready:
+			// The memory was acquired after ctx was done, so release it again.
+			l.inUse -= n
+			l.notify()
+		default:
+			front := l.waiters.Front() == elem
+			l.waiters.Remove(elem)
+
+			// The waiters behind the removed waiter may now fit.
+			if front && l.limit > l.inUse {
+				l.notify()
+			}
+		}
+
+		l.mu.Unlock()
+
+		return ctx.Err()
+	case <-ready:
+		return nil
+	}
+}
+
+// Release releases n bytes of memory previously acquired. It panics if more memory is released than is in use.
+func (l *Limiter) Release(n uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if n > l.inUse {
+		panic("memlimit: released more memory than was acquired")
+	}
+
+	l.inUse -= n
+	l.notify()
+}
+
+// notify grants memory to the waiters in order until the next waiter does not fit.
+func (l *Limiter) notify() {
+	for {
+		next := l.waiters.Front()
+		if next == nil {
+			return
+		}
+
+		w := next.Value.(waiter)
+		if l.limit-l.inUse < w.n {
+			return
+		}
+
+		l.inUse += w.n
+		l.waiters.Remove(next)
+		close(w.ready)
+	}
+}
@@ -0,0 +1,203 @@
+package memlimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiterTryAcquire(t *testing.T) {
+	l := NewLimiter(100, false)
+
+	if !l.TryAcquire(60) {
+		t.Fatalf("expected to acquire 60 of 100")
+	}
+
+	if l.TryAcquire(41) {
+		t.Errorf("expected not to acquire 41 with 60 of 100 in use")
+	}
+
+	if !l.TryAcquire(40) {
+		t.Errorf("expected to acquire 40 with 60 of 100 in use")
+	}
+
+	if l.InUse() != 100 {
+		t.Errorf("expected 100 in use but got %d", l.InUse())
+	}
+
+	l.Release(100)
+
+	if l.InUse() != 0 {
+		t.Errorf("expected 0 in use but got %d", l.InUse())
+	}
+}
+
+func TestLimiterAcquireNoWait(t *testing.T) {
+	l := NewLimiter(100, false)
+
+	if err := l.Acquire(context.Background(), 101); err != ErrExceedsLimit {
+		t.Errorf("got err %v but should have given %v", err, ErrExceedsLimit)
+	}
+
+	if err := l.Acquire(context.Background(), 100); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := l.Acquire(context.Background(), 1); err != ErrLimitReached {
+		t.Errorf("got err %v but should have given %v", err, ErrLimitReached)
+	}
+}
+
+func TestLimiterAcquireWait(t *testing.T) {
+	l := NewLimiter(100, true)
+
+	if err := l.Acquire(context.Background(), 101); err != ErrExceedsLimit {
+		t.Errorf("got err %v but should have given %v", err, ErrExceedsLimit)
+	}
+
+	if err := l.Acquire(context.Background(), 80); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	acquired := make(chan error)
+
+	go func() {
+		acquired <- l.Acquire(context.Background(), 50)
+	}()
+
+	select {
+	case err := <-acquired:
+		t.Fatalf("expected to wait for the memory but got %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	l.Release(80)
+
+	if err := <-acquired; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if l.InUse() != 50 {
+		t.Errorf("expected 50 in use but got %d", l.InUse())
+	}
+}
+
+func TestLimiterWaitOrder(t *testing.T) {
+	l := NewLimiter(100, true)
+
+	if err := l.Wait(context.Background(), 100); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	large := make(chan error)
+
+	go func() {
+		large <- l.Wait(context.Background(), 90)
+	}()
+
+	for l.waiting() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// A small request must not overtake the large request which is waiting.
+	if l.TryAcquire(10) {
+		t.Errorf("expected not to acquire ahead of a waiter")
+	}
+
+	l.Release(100)
+
+	if err := <-large; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !l.TryAcquire(10) {
+		t.Errorf("expected to acquire 10 with 90 of 100 in use")
+	}
+}
+
+func TestLimiterWaitContext(t *testing.T) {
+	l := NewLimiter(100, true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := l.Wait(ctx, 10); err != context.Canceled {
+		t.Errorf("got err %v but should have given %v", err, context.Canceled)
+	}
+
+	if err := l.Wait(context.Background(), 100); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx, 90); err != context.DeadlineExceeded {
+		t.Errorf("got err %v but should have given %v", err, context.DeadlineExceeded)
+	}
+
+	if l.waiting() != 0 {
+		t.Errorf("expected the cancelled waiter to be removed")
+	}
+
+	l.Release(100)
+
+	if l.InUse() != 0 {
+		t.Errorf("expected 0 in use but got %d", l.InUse())
+	}
+}
+
+func TestLimiterReleasePanics(t *testing.T) {
+	l := NewLimiter(100, true)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected Release to panic")
+		}
+	}()
+
+	l.Release(1)
+}
+
+func TestDefault(t *testing.T) {
+	defer SetDefault(nil)
+
+	release, err := Acquire(context.Background(), 1<<62)
+	if err != nil {
+		t.Fatalf("unexpected error without a default limiter: %v", err)
+	}
+
+	release()
+
+	l := NewLimiter(100, false)
+	SetDefault(l)
+
+	if Default() != l {
+		t.Fatalf("expected the default limiter to be set")
+	}
+
+	if release, err = Acquire(context.Background(), 100); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err = Acquire(context.Background(), 1); err != ErrLimitReached {
+		t.Errorf("got err %v but should have given %v", err, ErrLimitReached)
+	}
+
+	release()
+
+	if _, err = Wait(context.Background(), 101); err != ErrExceedsLimit {
+		t.Errorf("got err %v but should have given %v", err, ErrExceedsLimit)
+	}
+
+	if l.InUse() != 0 {
+		t.Errorf("expected 0 in use but got %d", l.InUse())
+	}
+}
+
+func (l *Limiter) waiting() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.waiters.Len()
+}
@@ -8,11 +8,13 @@
 package scrypt
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/binary"
 	"errors"
 	"math/bits"
 
+	"github.com/go-crypt/x/memlimit"
 	"github.com/go-crypt/x/pbkdf2"
 )
 
@@ -192,7 +194,18 @@ func smix(b []byte, r, N int, v, xy []uint32) {
 // and p=1. The parameters N, r, and p should be increased as memory latency and
 // CPU parallelism increases; consider setting N to the highest power of 2 you
 // can derive within 100 milliseconds. Remember to get a good random salt.
+//
+// If a default memlimit.Limiter is set the memory is acquired from it before
+// it is allocated, and its error is returned if the memory is not available. If
+// the Limiter waits, Key blocks until the memory is available for as long as it
+// takes, use KeyContext to stop waiting.
 func Key(password, salt []byte, N, r, p, keyLen int) ([]byte, error) {
+	return KeyContext(context.Background(), password, salt, N, r, p, keyLen)
+}
+
+// KeyContext is like Key but stops waiting for the memory once ctx is done,
+// and stops the derivation between the p blocks, returning ctx.Err().
+func KeyContext(ctx context.Context, password, salt []byte, N, r, p, keyLen int) ([]byte, error) {
 	if N <= 1 || N&(N-1) != 0 {
 		return nil, errors.New("scrypt: N must be > 1 and a power of 2")
 	}
@@ -200,11 +213,25 @@ func Key(password, salt []byte, N, r, p, keyLen int) ([]byte, error) {
 		return nil, errors.New("scrypt: parameters are too large")
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	release, err := memlimit.Acquire(ctx, 4*(64*uint64(r)+32*uint64(N)*uint64(r)))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	xy := make([]uint32, 64*r)
 	v := make([]uint32, 32*N*r)
 	b := pbkdf2.Key(password, salt, 1, p*128*r, sha256.New)
 
 	for i := 0; i < p; i++ {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		smix(b[i*128*r:], r, N, v, xy)
 	}
 
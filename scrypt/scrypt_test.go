@@ -6,7 +6,11 @@ package scrypt
 
 import (
 	"bytes"
+	"context"
 	"testing"
+	"time"
+
+	"github.com/go-crypt/x/memlimit"
 )
 
 type testVector struct {
@@ -153,6 +157,62 @@ func TestKey(t *testing.T) {
 	}
 }
 
+func TestKeyMemoryLimit(t *testing.T) {
+	defer memlimit.SetDefault(nil)
+
+	l := memlimit.NewLimiter(4*(64*8+32*1024*8), false)
+	memlimit.SetDefault(l)
+
+	if _, err := Key([]byte("password"), []byte("salt"), 1024, 8, 1, 32); err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+
+	if _, err := Key([]byte("password"), []byte("salt"), 2048, 8, 1, 32); err != memlimit.ErrExceedsLimit {
+		t.Errorf("expected error %v, got %v", memlimit.ErrExceedsLimit, err)
+	}
+
+	if l.InUse() != 0 {
+		t.Errorf("expected the memory to be released, got %d in use", l.InUse())
+	}
+}
+
+func TestKeyContext(t *testing.T) {
+	defer memlimit.SetDefault(nil)
+
+	l := memlimit.NewLimiter(4*(64*8+32*1024*8), true)
+	memlimit.SetDefault(l)
+
+	release, err := memlimit.Acquire(t.Context(), 1)
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+
+	// The memory is not available until released, so the derivation waits until ctx is done.
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err = KeyContext(ctx, []byte("password"), []byte("salt"), 1024, 8, 1, 32); err != context.DeadlineExceeded {
+		t.Errorf("expected error %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	release()
+
+	ctx, cancel = context.WithCancel(t.Context())
+	cancel()
+
+	if _, err = KeyContext(ctx, []byte("password"), []byte("salt"), 1024, 8, 1, 32); err != context.Canceled {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+	}
+
+	if _, err = KeyContext(t.Context(), []byte("password"), []byte("salt"), 1024, 8, 1, 32); err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+
+	if l.InUse() != 0 {
+		t.Errorf("expected the memory to be released, got %d in use", l.InUse())
+	}
+}
+
 var sink []byte
 
 func BenchmarkKey(b *testing.B) {
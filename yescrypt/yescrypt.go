@@ -13,12 +13,14 @@
 package yescrypt
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/binary"
 	"errors"
 	"math/bits"
 
+	"github.com/go-crypt/x/memlimit"
 	"github.com/go-crypt/x/pbkdf2"
 )
 
@@ -276,7 +278,7 @@ func smixYescrypt(b []byte, r, N int, v, xy []uint64, passwordSha256 []byte) {
 	smix(b, r, N, ((N+2)/3+1) & ^1, v, xy, &ctx)
 }
 
-func deriveKey(password, salt []byte, N, r, p, keyLen int) ([]byte, error) {
+func deriveKey(ctx context.Context, password, salt []byte, N, r, p, keyLen int) ([]byte, error) {
 	if N <= 1 || N&(N-1) != 0 {
 		return nil, errors.New("yescrypt: N must be > 1 and a power of 2")
 	}
@@ -297,6 +299,16 @@ func deriveKey(password, salt []byte, N, r, p, keyLen int) ([]byte, error) {
 	pass := 1
 	prehash := []byte("yescrypt-prehash")
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	release, err := memlimit.Acquire(ctx, 8*(16*uint64(N)*uint64(r)+16*uint64(max(r, 2))))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	v := make([]uint64, 16*N*r)
 	var key []byte
 
@@ -307,6 +319,10 @@ func deriveKey(password, salt []byte, N, r, p, keyLen int) ([]byte, error) {
 	}
 
 	for pass <= 1 {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		if pass == 1 {
 			prehash = prehash[:8]
 		}
@@ -348,6 +364,17 @@ func deriveKey(password, salt []byte, N, r, p, keyLen int) ([]byte, error) {
 //
 // The set of parameters accepted by Key will likely change in future versions
 // of this Go module to support more yescrypt functionality.
+//
+// If a default memlimit.Limiter is set the memory is acquired from it before
+// it is allocated, and its error is returned if the memory is not available. If
+// the Limiter waits, Key blocks until the memory is available for as long as it
+// takes, use KeyContext to stop waiting.
 func Key(password, salt []byte, N, r, p, keyLen int) ([]byte, error) {
-	return deriveKey(password, salt, N, r, p, keyLen)
+	return KeyContext(context.Background(), password, salt, N, r, p, keyLen)
+}
+
+// KeyContext is like Key but stops waiting for the memory once ctx is done,
+// and stops the derivation between its passes, returning ctx.Err().
+func KeyContext(ctx context.Context, password, salt []byte, N, r, p, keyLen int) ([]byte, error) {
+	return deriveKey(ctx, password, salt, N, r, p, keyLen)
 }
@@ -21,6 +21,9 @@ import (
 // setting or full hash encoding. The salt and other parameters are decoded
 // from setting.  Currently supports (only a little more than) the subset of
 // yescrypt parameters that libxcrypt can generate (as of libxcrypt 4.4.36).
+//
+// The memory is acquired from the default memlimit.Limiter as by Key, so Hash
+// also blocks until it is available if the Limiter waits.
 func Hash(password, setting []byte) ([]byte, error) {
 	if len(setting) < 7 || string(setting[:4]) != "$y$j" || setting[6] != '$' {
 		return nil, errors.New("yescrypt: unsupported parameters")